@@ -0,0 +1,139 @@
+package reversi
+
+import (
+	"math/bits"
+
+	bits64 "github.com/myoan/go-reversi/internal/bitboard"
+)
+
+// bitboard holds an 8x8 position as 64-bit masks. Bit y*8+x is set when the
+// cell at (x, y) holds a disc of that color, or a wall.
+type bitboard struct {
+	black uint64
+	white uint64
+	walls uint64
+}
+
+const bitboardSize = bits64.Size
+
+func newBitboard(init [][]int) (*bitboard, bool) {
+	if len(init) != bitboardSize {
+		return nil, false
+	}
+	bb := &bitboard{}
+	for y, line := range init {
+		if len(line) != bitboardSize {
+			return nil, false
+		}
+		for x, state := range line {
+			switch state {
+			case 0:
+			case 1:
+				bb.black |= bitAt(x, y)
+			case 2:
+				bb.white |= bitAt(x, y)
+			case 3:
+				bb.walls |= bitAt(x, y)
+			default:
+				return nil, false
+			}
+		}
+	}
+	return bb, true
+}
+
+func bitAt(x, y int) uint64 {
+	return bits64.Bit(x, y)
+}
+
+func (bb *bitboard) state(x, y int) Color {
+	bit := bitAt(x, y)
+	switch {
+	case bb.black&bit != 0:
+		return Black
+	case bb.white&bit != 0:
+		return White
+	case bb.walls&bit != 0:
+		return Wall
+	default:
+		return None
+	}
+}
+
+func (bb *bitboard) set(x, y int, state Color) {
+	bit := bitAt(x, y)
+	bb.black &^= bit
+	bb.white &^= bit
+	bb.walls &^= bit
+	switch state {
+	case Black:
+		bb.black |= bit
+	case White:
+		bb.white |= bit
+	case Wall:
+		bb.walls |= bit
+	}
+}
+
+// players returns the discs of color and of its opponent.
+func (bb *bitboard) players(color Color) (uint64, uint64) {
+	if color == Black {
+		return bb.black, bb.white
+	}
+	return bb.white, bb.black
+}
+
+func (bb *bitboard) count(color Color) int {
+	switch color {
+	case None:
+		return bits.OnesCount64(bb.empty())
+	case Black:
+		return bits.OnesCount64(bb.black)
+	case White:
+		return bits.OnesCount64(bb.white)
+	case Wall:
+		return bits.OnesCount64(bb.walls)
+	default:
+		return 0
+	}
+}
+
+func (bb *bitboard) occupied() bool {
+	return bb.empty() == 0
+}
+
+func (bb *bitboard) empty() uint64 {
+	return ^(bb.black | bb.white | bb.walls)
+}
+
+// moves returns the mask of empty cells where color can place a stone.
+func (bb *bitboard) moves(color Color) uint64 {
+	p, o := bb.players(color)
+	return bits64.Moves(p, o, bb.empty())
+}
+
+// frontier returns the discs of color that have an empty neighbour.
+func (bb *bitboard) frontier(color Color) uint64 {
+	p, _ := bb.players(color)
+	return bits64.Frontier(p, bb.empty())
+}
+
+// flips returns the mask of discs turned over when color plays at (x, y).
+func (bb *bitboard) flips(color Color, x, y int) uint64 {
+	p, o := bb.players(color)
+	move := bitAt(x, y)
+	if bb.empty()&move == 0 {
+		return 0
+	}
+	return bits64.Flips(p, o, move)
+}
+
+func maskPositions(m uint64) []*Position {
+	ret := make([]*Position, 0, bits.OnesCount64(m))
+	for m != 0 {
+		i := bits.TrailingZeros64(m)
+		ret = append(ret, &Position{X: i % bitboardSize, Y: i / bitboardSize})
+		m &= m - 1
+	}
+	return ret
+}
@@ -2,15 +2,51 @@ package reversi
 
 import (
 	"fmt"
+	"log/slog"
+	"os"
 )
 
 type Board struct {
 	Width  int
 	Height int
 	board  [][]*Cell
+	bits   *bitboard
+	hash   uint64
+	logger *slog.Logger
 }
 
+// NewBoard builds a board from a grid of cell states. 8x8 boards are backed by
+// a bitboard, other sizes by a cell grid. NewBoard panics with a *LayoutError
+// when init fails ValidateLayout; use BuildBoard for layouts that are not
+// known to be valid.
 func NewBoard(init [][]int) *Board {
+	b, err := BuildBoard(init)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// BuildBoard is like NewBoard but returns an error matching ErrLayout for a
+// layout that is empty, ragged or holds unknown cell states.
+func BuildBoard(init [][]int) (*Board, error) {
+	if err := ValidateLayout(init); err != nil {
+		return nil, err
+	}
+	return newBoard(init), nil
+}
+
+// newBoard builds a board from a layout that passed ValidateLayout.
+func newBoard(init [][]int) *Board {
+	if bb, ok := newBitboard(init); ok {
+		b := &Board{bits: bb, Width: bitboardSize, Height: bitboardSize}
+		b.hash = b.computeHash()
+		return b
+	}
+	return newGridBoard(init)
+}
+
+func newGridBoard(init [][]int) *Board {
 	height := len(init)
 	if height == 0 {
 		return &Board{board: [][]*Cell{}}
@@ -22,32 +58,63 @@ func NewBoard(init [][]int) *Board {
 	for i, line := range init {
 		bLine := make([]*Cell, width)
 		for j, state := range line {
-			bLine[j] = &Cell{X: j, Y: i, State: state}
+			bLine[j] = &Cell{X: j, Y: i, State: Color(state)}
 		}
 		board[i] = bLine
 	}
-	return &Board{board: board, Width: width, Height: height}
+	b := &Board{board: board, Width: width, Height: height}
+	b.hash = b.computeHash()
+	return b
 }
 
+// GetBoard returns a copy of the cells of the board. Changes made to the
+// returned cells are not reflected on the board; use SetStone to play moves.
 func (b *Board) GetBoard() [][]*Cell {
-	return b.board
+	ret := make([][]*Cell, b.Height)
+	for i := range ret {
+		line := make([]*Cell, b.Width)
+		for j := range line {
+			line[j] = b.Cell(j, i)
+		}
+		ret[i] = line
+	}
+	return ret
 }
 
+// Cell returns a copy of the cell at (x, y), or nil outside the board.
+// Changes made to the returned cell are not reflected on the board.
 func (b *Board) Cell(x, y int) *Cell {
+	c := b.cell(x, y)
+	if c == nil || b.bits != nil {
+		return c
+	}
+	ret := *c
+	return &ret
+}
+
+// cell returns the cell at (x, y), or nil outside the board. On grid-backed
+// boards it is the live cell, which only set may change.
+func (b *Board) cell(x, y int) *Cell {
 	if x < 0 || x >= b.Width {
 		return nil
 	}
 	if y < 0 || y >= b.Height {
 		return nil
 	}
+	if b.bits != nil {
+		return &Cell{X: x, Y: y, State: b.bits.state(x, y)}
+	}
 	return b.board[y][x]
 }
 
-func (b *Board) Count(color int) int {
+func (b *Board) Count(color Color) int {
+	if b.bits != nil {
+		return b.bits.count(color)
+	}
 	ret := 0
 	for i := 0; i < b.Height; i++ {
 		for j := 0; j < b.Width; j++ {
-			c := b.Cell(i, j)
+			c := b.cell(j, i)
 			if c.State == color {
 				ret++
 			}
@@ -56,29 +123,19 @@ func (b *Board) Count(color int) int {
 	return ret
 }
 
+// Show prints the board to stdout in plain ASCII.
 func (b *Board) Show() {
-	fmt.Println("----------------------")
-	fmt.Println("    0 1 2 3 4 5 6 7")
-	for i := 0; i < b.Height; i++ {
-		fmt.Printf(" %d  ", i)
-		for j := 0; j < b.Width; j++ {
-			cell := b.board[i][j]
-			switch cell.State {
-			case 0:
-				fmt.Print("_")
-			case 1:
-				fmt.Print("x")
-			case 2:
-				fmt.Print("o")
-			}
-			fmt.Print(" ")
-		}
-		fmt.Println("")
-	}
+	b.Render(os.Stdout)
 	fmt.Println("")
 }
 
-func (b *Board) ListAllocatablePositions(color int) []*Position {
+func (b *Board) ListAllocatablePositions(color Color) []*Position {
+	if !color.Valid() {
+		return []*Position{}
+	}
+	if b.bits != nil {
+		return maskPositions(b.bits.moves(color))
+	}
 	ret := []*Position{}
 	for i := 0; i < b.Height; i++ {
 		for j := 0; j < b.Width; j++ {
@@ -91,26 +148,96 @@ func (b *Board) ListAllocatablePositions(color int) []*Position {
 	return ret
 }
 
-func (b *Board) SetStone(color int, pos *Position) error {
-	fmt.Printf("SetStone: (%d, %d) color: %d\n", pos.X, pos.Y, color)
-	cell := b.Cell(pos.X, pos.Y)
+func (b *Board) SetStone(color Color, pos *Position) error {
+	_, err := b.play(color, pos)
+	return err
+}
+
+// play works like SetStone and also returns the discs it flipped.
+func (b *Board) play(color Color, pos *Position) ([]*Position, error) {
+	flipped, err := b.check(color, pos)
+	if err != nil {
+		logReject(b.logger, color, pos, err)
+		return nil, err
+	}
+	b.replay(color, pos, flipped)
+	logMove(b.logger, color, pos, flipped)
+	return flipped, nil
+}
+
+// check validates a move by color and returns the discs it would flip.
+func (b *Board) check(color Color, pos *Position) ([]*Position, error) {
+	if err := validateColor(color); err != nil {
+		return nil, err
+	}
+	cell := b.cell(pos.X, pos.Y)
 	if cell == nil {
-		return fmt.Errorf("Cell not found at (%d, %d)", pos.X, pos.Y)
+		return nil, illegalMove(color, pos, ErrOutOfBounds)
+	}
+	if cell.State != None {
+		return nil, illegalMove(color, pos, ErrOccupied)
+	}
+	flipped := b.flipped(color, cell)
+	if len(flipped) == 0 {
+		return nil, illegalMove(color, pos, ErrNoFlips)
 	}
-	if cell.State != int(None) {
-		return fmt.Errorf("Not empty cell (%d, %d)", pos.X, pos.Y)
+	return flipped, nil
+}
+
+// unplay reverts a move made by play.
+func (b *Board) unplay(color Color, pos *Position, flipped []*Position) {
+	opponent := color.Opponent()
+	for _, f := range flipped {
+		b.set(f.X, f.Y, opponent)
 	}
-	if err := b.allocate(color, cell); err != nil {
-		return fmt.Errorf("Cell not allocate (%d, %d)", pos.X, pos.Y)
+	b.set(pos.X, pos.Y, None)
+}
+
+// replay applies a move whose flipped discs are already known.
+func (b *Board) replay(color Color, pos *Position, flipped []*Position) {
+	b.set(pos.X, pos.Y, color)
+	for _, f := range flipped {
+		b.set(f.X, f.Y, color)
 	}
-	return nil
+}
+
+func (b *Board) set(x, y int, state Color) {
+	b.hash ^= b.cellKey(x, y, b.cell(x, y).State) ^ b.cellKey(x, y, state)
+	if b.bits != nil {
+		b.bits.set(x, y, state)
+		return
+	}
+	b.board[y][x].Update(state)
+}
+
+// flipped lists the discs that color would flip by playing on cell.
+func (b *Board) flipped(color Color, cell *Cell) []*Position {
+	if b.bits != nil {
+		return maskPositions(b.bits.flips(color, cell.X, cell.Y))
+	}
+	ret := []*Position{}
+	if cell.State != None {
+		return ret
+	}
+	for _, d := range directions {
+		if !b.seek(d, color, cell) {
+			continue
+		}
+		for next, _ := b.next(d, cell); next != nil && next.State != color; next, _ = b.next(d, next) {
+			ret = append(ret, &Position{X: next.X, Y: next.Y})
+		}
+	}
+	return ret
 }
 
 func (b *Board) IsOccupied() bool {
+	if b.bits != nil {
+		return b.bits.occupied()
+	}
 	for i := 0; i < b.Height; i++ {
 		for j := 0; j < b.Width; j++ {
-			c := b.Cell(i, j)
-			if c.State == 0 {
+			c := b.cell(j, i)
+			if c.State == None {
 				return false
 			}
 		}
@@ -118,28 +245,21 @@ func (b *Board) IsOccupied() bool {
 	return true
 }
 
+// Opponent returns the opponent of color.
+//
+// Deprecated: use Color.Opponent.
 func (b *Board) Opponent(color int) int {
-	if color == 1 {
-		return 2
-	}
-	return 1
+	return int(Color(color).Opponent())
 }
 
-func (b *Board) canAllocate(color int, cell *Cell) bool {
-	if cell.State != 0 {
+func (b *Board) canAllocate(color Color, cell *Cell) bool {
+	if cell.State != None {
 		return false
 	}
-	var ds = []*Direction{
-		{dx: 0, dy: -1},  // top
-		{dx: 1, dy: -1},  // top right
-		{dx: 1, dy: 0},   // right
-		{dx: 1, dy: 1},   // bottom right
-		{dx: 0, dy: 1},   // bottom
-		{dx: -1, dy: 1},  // bottom left
-		{dx: -1, dy: 0},  // left
-		{dx: -1, dy: -1}, // top left
-	}
-	for _, d := range ds {
+	if b.bits != nil {
+		return b.bits.flips(color, cell.X, cell.Y) != 0
+	}
+	for _, d := range directions {
 		if b.seek(d, color, cell) {
 			return true
 		}
@@ -149,29 +269,27 @@ func (b *Board) canAllocate(color int, cell *Cell) bool {
 
 func (b *Board) toArray() [][]int {
 	ret := make([][]int, 0, b.Height)
-	for _, line := range b.board {
+	for i := 0; i < b.Height; i++ {
 		a := make([]int, 0, b.Width)
-		for _, cell := range line {
-			a = append(a, cell.State)
+		for j := 0; j < b.Width; j++ {
+			a = append(a, int(b.cell(j, i).State))
 		}
 		ret = append(ret, a)
 	}
 	return ret
 }
 
-func (b *Board) allocate(color int, cell *Cell) error {
+func (b *Board) allocate(color Color, cell *Cell) error {
+	if b.bits != nil {
+		flipped := b.flipped(color, cell)
+		if len(flipped) == 0 {
+			return fmt.Errorf("Failed to allocate at (%d, %d)", cell.X, cell.Y)
+		}
+		b.replay(color, &Position{X: cell.X, Y: cell.Y}, flipped)
+		return nil
+	}
 	var allocated = false
-	var ds = []*Direction{
-		{dx: 0, dy: -1},  // top
-		{dx: 1, dy: -1},  // top right
-		{dx: 1, dy: 0},   // right
-		{dx: 1, dy: 1},   // bottom right
-		{dx: 0, dy: 1},   // bottom
-		{dx: -1, dy: 1},  // bottom left
-		{dx: -1, dy: 0},  // left
-		{dx: -1, dy: -1}, // top left
-	}
-	for _, d := range ds {
+	for _, d := range directions {
 		if b.seek(d, color, cell) {
 			b.update(d, color, cell)
 			allocated = true
@@ -191,17 +309,17 @@ func (b *Board) next(d *Direction, cell *Cell) (*Cell, error) {
 	if y < 0 || y >= b.Height {
 		return nil, fmt.Errorf("Invalid position")
 	}
-	return b.Cell(x, y), nil
+	return b.cell(x, y), nil
 }
 
-func (b *Board) seek(d *Direction, color int, cell *Cell) bool {
+func (b *Board) seek(d *Direction, color Color, cell *Cell) bool {
 	x, y := d.Next(cell.X, cell.Y)
-	next := b.Cell(x, y)
+	next := b.cell(x, y)
 	if next == nil {
 		return false
 	}
 
-	opponent := b.Opponent(color)
+	opponent := color.Opponent()
 
 	switch next.State {
 	case opponent:
@@ -216,14 +334,14 @@ func (b *Board) seek(d *Direction, color int, cell *Cell) bool {
 	}
 }
 
-func (b *Board) update(d *Direction, color int, cell *Cell) {
+func (b *Board) update(d *Direction, color Color, cell *Cell) {
 	x, y := d.Next(cell.X, cell.Y)
-	next := b.Cell(x, y)
+	next := b.cell(x, y)
 	if next == nil {
 		return
 	}
-	cell.Update(color)
-	opponent := b.Opponent(color)
+	b.set(cell.X, cell.Y, color)
+	opponent := color.Opponent()
 
 	if next.State == opponent {
 		b.update(d, color, next)
@@ -1,7 +1,9 @@
 package reversi
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
 	"testing"
 )
 
@@ -61,7 +63,8 @@ func TestBoard_Cell(t *testing.T) {
 			expected: nil,
 		},
 	}
-	b := NewBoard([][]int{
+	// Unknown states are only reachable through the unchecked grid path.
+	b := newGridBoard([][]int{
 		{0, 1, 2, 3},
 		{4, 5, 6, 7},
 		{8, 9, 10, 11},
@@ -79,6 +82,114 @@ func TestBoard_Cell(t *testing.T) {
 	}
 }
 
+func TestBoard_Cell_copy(t *testing.T) {
+	for _, size := range []int{6, 8} {
+		b := NewBoard(DefaultLayout(size))
+		b.Cell(0, 0).Update(Black)
+		b.GetBoard()[0][1].Update(White)
+		if b.Cell(0, 0).State != None || b.Cell(1, 0).State != None {
+			t.Errorf("%dx%d, got: %s and %s, expected: unchanged cells", size, size, b.Cell(0, 0).State, b.Cell(1, 0).State)
+		}
+		if b.Hash() != b.computeHash() {
+			t.Errorf("%dx%d, got: %x, expected: %x", size, size, b.Hash(), b.computeHash())
+		}
+	}
+}
+
+func TestBuildBoard(t *testing.T) {
+	testcases := []struct {
+		desc string
+		init [][]int
+		row  int
+		col  int
+	}{
+		{desc: "when empty", init: [][]int{}, row: -1, col: -1},
+		{
+			desc: "when a row is longer",
+			init: [][]int{
+				{0, 0, 0, 0},
+				{0, 1, 2, 0, 0},
+				{0, 2, 1, 0},
+				{0, 0, 0, 0},
+			},
+			row: 1,
+			col: -1,
+		},
+		{
+			desc: "when a row is shorter",
+			init: [][]int{
+				{0, 0, 0, 0},
+				{0, 1, 2},
+				{0, 2, 1, 0},
+				{0, 0, 0, 0},
+			},
+			row: 1,
+			col: -1,
+		},
+		{
+			desc: "when an unknown state",
+			init: [][]int{
+				{0, 0, 0, 0},
+				{0, 1, 2, 0},
+				{0, 2, 1, 7},
+				{0, 0, 0, 0},
+			},
+			row: 2,
+			col: 3,
+		},
+		{
+			desc: "when an 8x8 board has a short row",
+			init: append([][]int{{0, 0, 0}}, InitBoard[1:]...),
+			row:  1,
+			col:  -1,
+		},
+	}
+	for _, tc := range testcases {
+		b, err := BuildBoard(tc.init)
+		var layoutErr *LayoutError
+		if b != nil || !errors.As(err, &layoutErr) || !errors.Is(err, ErrLayout) {
+			t.Errorf("%s, got: %v, expected: a layout error", tc.desc, err)
+			continue
+		}
+		if layoutErr.Row != tc.row || layoutErr.Col != tc.col {
+			t.Errorf("%s, got: (%d, %d), expected: (%d, %d)", tc.desc, layoutErr.Col, layoutErr.Row, tc.col, tc.row)
+		}
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s, got: no panic from NewBoard", tc.desc)
+				}
+			}()
+			NewBoard(tc.init)
+		}()
+	}
+
+	b, err := BuildBoard(DefaultLayout(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Equal(NewBoard(DefaultLayout(6))) {
+		t.Errorf("got: %v, expected: the default 6x6 board", b.toArray())
+	}
+}
+
+func TestBoard_Opponent(t *testing.T) {
+	b := NewBoard(InitBoard)
+	testcases := []struct {
+		desc     string
+		color    int
+		expected int
+	}{
+		{desc: "when black", color: 1, expected: 2},
+		{desc: "when white", color: 2, expected: 1},
+	}
+	for _, tc := range testcases {
+		if actual := b.Opponent(tc.color); actual != tc.expected {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, actual, tc.expected)
+		}
+	}
+}
+
 func TestBoard_next(t *testing.T) {
 	testcases := []struct {
 		desc     string
@@ -452,7 +563,7 @@ func TestBoard_seek(t *testing.T) {
 	for _, tc := range testcases {
 		b := NewBoard(tc.board)
 		cell := b.Cell(tc.pos.X, tc.pos.Y)
-		actual := b.seek(tc.d, int(Black), cell)
+		actual := b.seek(tc.d, Black, cell)
 		if tc.expected != actual {
 			t.Errorf("%s, got: %v, expected: %v", tc.desc, actual, tc.expected)
 			fmt.Println("actual")
@@ -540,7 +651,7 @@ func TestBoard_update(t *testing.T) {
 	for _, tc := range testcases {
 		b := NewBoard(tc.board)
 		cell := b.Cell(tc.pos.X, tc.pos.Y)
-		b.update(tc.d, int(Black), cell)
+		b.update(tc.d, Black, cell)
 		if !matchArray(tc.expected, b.toArray()) {
 			t.Errorf("%s", tc.desc)
 			fmt.Println("actual")
@@ -609,7 +720,7 @@ func TestBoard_allocate(t *testing.T) {
 	for _, tc := range testcases {
 		b := NewBoard(tc.board)
 		cell := b.Cell(tc.pos.X, tc.pos.Y)
-		b.allocate(int(Black), cell)
+		b.allocate(Black, cell)
 		if !matchArray(tc.expected, b.toArray()) {
 			t.Errorf("%s", tc.desc)
 			fmt.Println("actual")
@@ -685,7 +796,7 @@ func TestBoard_canAllocate(t *testing.T) {
 	for _, tc := range testcases {
 		b := NewBoard(tc.board)
 		cell := b.Cell(tc.pos.X, tc.pos.Y)
-		actual := b.canAllocate(int(Black), cell)
+		actual := b.canAllocate(Black, cell)
 		if tc.expected != actual {
 			t.Errorf("%s", tc.desc)
 			fmt.Println("actual")
@@ -734,7 +845,7 @@ func TestBoard_Count(t *testing.T) {
 
 	for _, tc := range testcases {
 		b := NewBoard(tc.board)
-		actual := b.Count(int(Black))
+		actual := b.Count(Black)
 		if actual != tc.expect {
 			t.Errorf("%s", tc.desc)
 			fmt.Printf("expect: %d, actual: %d\n", tc.expect, actual)
@@ -802,7 +913,7 @@ func TestBoard_ListAllocatablePositions(t *testing.T) {
 
 	for _, tc := range testcases {
 		b := NewBoard(tc.board)
-		actual := b.ListAllocatablePositions(int(Black))
+		actual := b.ListAllocatablePositions(Black)
 		if tc.expect != len(actual) {
 			t.Errorf("%s", tc.desc)
 			fmt.Printf("expect: %d, actual:\n", tc.expect)
@@ -812,3 +923,252 @@ func TestBoard_ListAllocatablePositions(t *testing.T) {
 		}
 	}
 }
+
+func TestBoard_bitboardMatchesGrid(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for game := 0; game < 50; game++ {
+		bit := NewBoard(InitBoard)
+		grid := newGridBoard(InitBoard)
+		if bit.bits == nil || grid.bits != nil {
+			t.Fatalf("unexpected engines: bit: %v, grid: %v", bit.bits != nil, grid.bits != nil)
+		}
+		color := Black
+		for passes := 0; passes < 2; {
+			bitMoves := bit.ListAllocatablePositions(color)
+			gridMoves := grid.ListAllocatablePositions(color)
+			if len(bitMoves) != len(gridMoves) {
+				t.Fatalf("game %d: moves mismatch, bitboard: %d, grid: %d", game, len(bitMoves), len(gridMoves))
+			}
+			for i := range bitMoves {
+				if *bitMoves[i] != *gridMoves[i] {
+					t.Fatalf("game %d: move %d mismatch, bitboard: %v, grid: %v", game, i, bitMoves[i], gridMoves[i])
+				}
+			}
+			for _, c := range []Color{Black, White} {
+				if bit.Mobility(c) != grid.Mobility(c) || bit.Frontier(c) != grid.Frontier(c) {
+					t.Fatalf("game %d: mobility or frontier mismatch for %s", game, c)
+				}
+			}
+			bitCandidates := bit.ListCandidates(color)
+			gridCandidates := grid.ListCandidates(color)
+			for i := range bitCandidates {
+				if *bitCandidates[i] != *gridCandidates[i] {
+					t.Fatalf("game %d: candidate %d mismatch, bitboard: %v, grid: %v", game, i, bitCandidates[i], gridCandidates[i])
+				}
+			}
+			if len(bitMoves) == 0 {
+				passes++
+				color = color.Opponent()
+				continue
+			}
+			passes = 0
+			pos := bitMoves[rnd.Intn(len(bitMoves))]
+			if err := bit.SetStone(color, pos); err != nil {
+				t.Fatal(err)
+			}
+			if err := grid.SetStone(color, pos); err != nil {
+				t.Fatal(err)
+			}
+			if !matchArray(bit.toArray(), grid.toArray()) {
+				t.Errorf("game %d: boards differ after (%d, %d)", game, pos.X, pos.Y)
+				bit.Show()
+				grid.Show()
+				return
+			}
+			color = color.Opponent()
+		}
+		for _, color := range []Color{None, Black, White} {
+			if bit.Count(color) != grid.Count(color) {
+				t.Errorf("game %d: count mismatch for %d", game, color)
+			}
+		}
+		if bit.IsOccupied() != grid.IsOccupied() {
+			t.Errorf("game %d: IsOccupied mismatch", game)
+		}
+	}
+}
+
+func benchmarkListAllocatablePositions(bench *testing.B, b *Board) {
+	for i := 0; i < bench.N; i++ {
+		b.ListAllocatablePositions(Black)
+		b.ListAllocatablePositions(White)
+	}
+}
+
+func BenchmarkBoard_ListAllocatablePositions_Bitboard(bench *testing.B) {
+	benchmarkListAllocatablePositions(bench, NewBoard(InitBoard))
+}
+
+func BenchmarkBoard_ListAllocatablePositions_Grid(bench *testing.B) {
+	benchmarkListAllocatablePositions(bench, newGridBoard(InitBoard))
+}
+
+func TestBoard_SetStone_invalidColor(t *testing.T) {
+	for _, color := range []Color{None, Color(3), Color(-1)} {
+		b := NewBoard(InitBoard)
+		err := b.SetStone(color, &Position{X: 5, Y: 4})
+		var colorErr *InvalidColorError
+		if !errors.Is(err, ErrInvalidColor) || !errors.As(err, &colorErr) || colorErr.Color != color {
+			t.Errorf("color %d, got: %v, expected: InvalidColorError", int(color), err)
+		}
+		if !matchArray(b.toArray(), InitBoard) {
+			t.Errorf("color %d, board changed", int(color))
+		}
+		if len(b.ListAllocatablePositions(color)) != 0 {
+			t.Errorf("color %d, has allocatable positions", int(color))
+		}
+	}
+}
+
+func TestBoard_Clone(t *testing.T) {
+	for _, b := range []*Board{NewBoard(InitBoard), newGridBoard(InitBoard)} {
+		clone := b.Clone()
+		if !clone.Equal(b) || !b.Equal(clone) {
+			t.Fatalf("clone differs from original")
+		}
+		if err := clone.SetStone(Black, &Position{X: 5, Y: 4}); err != nil {
+			t.Fatal(err)
+		}
+		if clone.Equal(b) {
+			t.Errorf("clone still equals original after a move")
+		}
+		if !matchArray(b.toArray(), InitBoard) {
+			t.Errorf("move on clone changed the original")
+			b.Show()
+		}
+	}
+}
+
+func TestBoard_Equal(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		a        *Board
+		b        *Board
+		expected bool
+	}{
+		{
+			desc:     "when bitboard and grid hold the same position",
+			a:        NewBoard(InitBoard),
+			b:        newGridBoard(InitBoard),
+			expected: true,
+		},
+		{
+			desc:     "when sizes differ",
+			a:        NewBoard([][]int{{0, 1}, {2, 0}}),
+			b:        NewBoard([][]int{{0, 1, 0}, {2, 0, 0}}),
+			expected: false,
+		},
+		{
+			desc:     "when a cell differs",
+			a:        NewBoard([][]int{{0, 1}, {2, 0}}),
+			b:        NewBoard([][]int{{0, 1}, {2, 1}}),
+			expected: false,
+		},
+		{
+			desc:     "when other is nil",
+			a:        NewBoard(InitBoard),
+			b:        nil,
+			expected: false,
+		},
+	}
+	for _, tc := range testcases {
+		if actual := tc.a.Equal(tc.b); actual != tc.expected {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, actual, tc.expected)
+		}
+	}
+}
+
+func TestBoard_Snapshot(t *testing.T) {
+	for _, b := range []*Board{NewBoard(InitBoard), newGridBoard(InitBoard)} {
+		snapshot := b.Snapshot()
+		snapshot[0][0].Update(Black)
+		snapshot[3][3].State = White
+		if !matchArray(b.toArray(), InitBoard) {
+			t.Errorf("changing the snapshot changed the board")
+		}
+	}
+}
+
+func TestBoard_Flips(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		board    [][]int
+		pos      *Position
+		expected []*Position
+	}{
+		{
+			desc: "when dual line",
+			board: [][]int{
+				{0, 0, 0, 0},
+				{0, 2, 2, 0},
+				{0, 1, 0, 1},
+				{0, 0, 0, 0},
+			},
+			pos:      &Position{X: 1, Y: 0},
+			expected: []*Position{{X: 1, Y: 1}, {X: 2, Y: 1}},
+		},
+		{
+			desc: "when some line finish no my color",
+			board: [][]int{
+				{0, 0, 2, 2},
+				{0, 2, 2, 0},
+				{0, 1, 0, 1},
+				{0, 0, 0, 0},
+			},
+			pos:      &Position{X: 1, Y: 0},
+			expected: []*Position{{X: 1, Y: 1}, {X: 2, Y: 1}},
+		},
+		{
+			desc:     "when cell is occupied",
+			board:    InitBoard,
+			pos:      &Position{X: 3, Y: 3},
+			expected: []*Position{},
+		},
+		{
+			desc:     "when initial board",
+			board:    InitBoard,
+			pos:      &Position{X: 5, Y: 4},
+			expected: []*Position{{X: 4, Y: 4}},
+		},
+	}
+
+	for _, tc := range testcases {
+		b := NewBoard(tc.board)
+		actual := b.Flips(Black, tc.pos)
+		if !matchArray(b.toArray(), tc.board) {
+			t.Errorf("%s, Flips changed the board", tc.desc)
+		}
+		if len(actual) != len(tc.expected) {
+			t.Errorf("%s, got: %d flips, expected: %d", tc.desc, len(actual), len(tc.expected))
+			continue
+		}
+		for _, e := range tc.expected {
+			found := false
+			for _, a := range actual {
+				found = found || *a == *e
+			}
+			if !found {
+				t.Errorf("%s, (%d, %d) not flipped", tc.desc, e.X, e.Y)
+			}
+		}
+
+		applied, err := b.Apply(Black, tc.pos)
+		if len(tc.expected) == 0 {
+			if err == nil {
+				t.Errorf("%s, Apply accepted an illegal move", tc.desc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !matchArray(b.toArray(), tc.board) {
+			t.Errorf("%s, Apply changed the board", tc.desc)
+		}
+		for _, e := range append(tc.expected, tc.pos) {
+			if applied.Cell(e.X, e.Y).State != Black {
+				t.Errorf("%s, (%d, %d) is not black after Apply", tc.desc, e.X, e.Y)
+			}
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Package book is an opening book: statistics on the moves played from
+// positions near the start of the game, learned from game records.
+//
+// Positions are stored in canonical form (see reversi.Board.Canonical), so a
+// position and its rotations and reflections share one entry, and moves are
+// mapped to and from the board they are looked up on.
+package book
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	reversi "github.com/myoan/go-reversi"
+	"github.com/myoan/go-reversi/engine"
+)
+
+var (
+	ErrBook       = errors.New("invalid book")
+	ErrUnfinished = errors.New("game is not finished")
+)
+
+// DefaultMaxPly is the number of plies of each game added to a book unless
+// MaxPly says otherwise.
+const DefaultMaxPly = 24
+
+// Book maps positions to the moves played from them. A Book is not safe for
+// concurrent use while games are being added.
+type Book struct {
+	// MaxPly is the number of plies of each game added to the book, passes
+	// included. 0 means DefaultMaxPly.
+	MaxPly int
+
+	nodes map[uint64]*node
+}
+
+type node struct {
+	board *reversi.Board // canonical
+	color reversi.Color  // side to move
+	// symmetries are the transforms other than Identity leaving board
+	// unchanged, under which several moves are the same move.
+	symmetries []reversi.Transform
+	moves      []*edge
+}
+
+type edge struct {
+	move      int // y*width+x on the canonical board
+	games     int
+	points    int // half points of the side to move: 2 a win, 1 a draw
+	eval      int
+	evaluated bool
+}
+
+// New returns an empty book.
+func New() *Book {
+	return &Book{nodes: map[uint64]*node{}}
+}
+
+// Len returns the number of positions in the book.
+func (b *Book) Len() int {
+	return len(b.nodes)
+}
+
+func (b *Book) maxPly() int {
+	if b.MaxPly <= 0 {
+		return DefaultMaxPly
+	}
+	return b.MaxPly
+}
+
+// AddGame adds the opening of a finished game, played from its starting
+// position, with the result decided by the rules of the game. It returns
+// ErrUnfinished when the game is not over.
+func (b *Book) AddGame(game *reversi.Game) error {
+	if game.GameState != reversi.Finish {
+		return ErrUnfinished
+	}
+	return b.add(game, game.Winner())
+}
+
+// AddTranscript adds a finished game given as a transcript such as
+// "f5d6c3...", played on a game built with opts.
+func (b *Book) AddTranscript(transcript string, opts ...reversi.Option) error {
+	game, err := reversi.ParseTranscript(transcript, opts...)
+	if err != nil {
+		return err
+	}
+	return b.AddGame(game)
+}
+
+// AddWthor adds every game of a WTHOR .wtb file and returns how many were
+// added. The result of a game is its recorded score, so games stopped before
+// the end count too.
+func (b *Book) AddWthor(r io.Reader) (int, error) {
+	wr, err := reversi.NewWthorReader(r)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for {
+		g, err := wr.Next()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		game, err := g.Game()
+		if err != nil {
+			return n, err
+		}
+		winner := reversi.None
+		switch {
+		case g.Score > 32:
+			winner = reversi.Black
+		case g.Score < 32:
+			winner = reversi.White
+		}
+		if err := b.add(game, winner); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// add walks the first plies of game, crediting each move with the result.
+func (b *Book) add(game *reversi.Game, winner reversi.Color) error {
+	g := game.Clone()
+	g.SetLogger(nil)
+	history := g.History()
+	if err := g.JumpTo(0); err != nil {
+		return err
+	}
+	// Redo steps over the passes, so the current ply always indexes a move.
+	for g.Ply() < len(history) && g.Ply() < b.maxPly() {
+		m := history[g.Ply()]
+		if n, t := b.node(g.Board(), m.Color, true); n != nil {
+			e := n.edge(t, m.Pos, true)
+			e.games++
+			switch winner {
+			case m.Color:
+				e.points += 2
+			case reversi.None:
+				e.points++
+			}
+		}
+		if !g.Redo() {
+			return fmt.Errorf("%w: cannot replay ply %d", ErrBook, g.Ply()+1)
+		}
+	}
+	return nil
+}
+
+// node returns the node of board with color to move and the transform taking
+// board to it, creating the node when create is set.
+func (b *Book) node(board *reversi.Board, color reversi.Color, create bool) (*node, reversi.Transform) {
+	canonical, t := board.Canonical()
+	key := canonical.HashTurn(color)
+	n, ok := b.nodes[key]
+	if ok && n.color == color && n.board.Equal(canonical) {
+		return n, t
+	}
+	if !create || ok {
+		// A hash collision keeps the position that came first.
+		return nil, t
+	}
+	n = newNode(canonical, color)
+	b.nodes[key] = n
+	return n, t
+}
+
+func newNode(canonical *reversi.Board, color reversi.Color) *node {
+	n := &node{board: canonical, color: color}
+	for _, s := range canonical.Symmetries()[1:] {
+		if canonical.Transform(s).Equal(canonical) {
+			n.symmetries = append(n.symmetries, s)
+		}
+	}
+	return n
+}
+
+// edge returns the edge of the move pos, on the board taken to n by t,
+// creating it when create is set.
+func (n *node) edge(t reversi.Transform, pos *reversi.Position, create bool) *edge {
+	move := n.index(t, pos)
+	for _, e := range n.moves {
+		if e.move == move {
+			return e
+		}
+	}
+	if !create {
+		return nil
+	}
+	e := &edge{move: move}
+	n.moves = append(n.moves, e)
+	return e
+}
+
+// index maps pos to the canonical board and returns its index. Moves that
+// are the same up to a symmetry of the position share the smallest index.
+func (n *node) index(t reversi.Transform, pos *reversi.Position) int {
+	w, h := n.board.Width, n.board.Height
+	if t.Swaps() {
+		w, h = h, w
+	}
+	p := t.Position(pos, w, h)
+	ret := p.Y*n.board.Width + p.X
+	for _, s := range n.symmetries {
+		q := s.Position(p, n.board.Width, n.board.Height)
+		if i := q.Y*n.board.Width + q.X; i < ret {
+			ret = i
+		}
+	}
+	return ret
+}
+
+// position maps the canonical move index back to the board taken to n by t.
+func (n *node) position(t reversi.Transform, index int) *reversi.Position {
+	p := &reversi.Position{X: index % n.board.Width, Y: index / n.board.Width}
+	return t.Inverse().Position(p, n.board.Width, n.board.Height)
+}
+
+// SetEval records eval as the evaluation of playing pos on board with color
+// to move, from the point of view of color. The position and move are added
+// to the book when missing.
+func (b *Book) SetEval(board *reversi.Board, color reversi.Color, pos *reversi.Position, eval int) {
+	n, t := b.node(board, color, true)
+	if n == nil {
+		return
+	}
+	e := n.edge(t, pos, true)
+	e.eval, e.evaluated = eval, true
+}
+
+// Evaluate searches every move played at least minGames times depth plies
+// deep with eng and records the scores as their evaluations. It returns
+// ctx.Err() when ctx ends first, keeping the evaluations made so far.
+func (b *Book) Evaluate(ctx context.Context, eng *engine.Engine, depth, minGames int) error {
+	for _, key := range b.keys() {
+		n := b.nodes[key]
+		for _, e := range n.moves {
+			if e.games < minGames {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			pos := &reversi.Position{X: e.move % n.board.Width, Y: e.move / n.board.Width}
+			next, err := n.board.Apply(n.color, pos)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrBook, err)
+			}
+			r := eng.Search(next, n.color.Opponent(), depth-1)
+			e.eval, e.evaluated = -r.Score, true
+		}
+	}
+	return nil
+}
+
+// keys returns the keys of the nodes in increasing order.
+func (b *Book) keys() []uint64 {
+	ret := make([]uint64, 0, len(b.nodes))
+	for k := range b.nodes {
+		ret = append(ret, k)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+	return ret
+}
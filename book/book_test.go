@@ -0,0 +1,214 @@
+package book
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"testing"
+
+	reversi "github.com/myoan/go-reversi"
+	"github.com/myoan/go-reversi/engine"
+)
+
+// randomGame plays random moves from the standard start to the end.
+func randomGame(t *testing.T, seed int64) *reversi.Game {
+	t.Helper()
+	game, err := reversi.NewGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.SetLogger(nil)
+	game.Start()
+	rng := rand.New(rand.NewSource(seed))
+	for game.GameState != reversi.Finish {
+		color := game.GameState.Turn()
+		moves := game.ListAllocatablePositions(color)
+		if err := game.SetStone(color, moves[rng.Intn(len(moves))]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return game
+}
+
+// transformGame replays game with every move transformed by tr, which must
+// leave the starting position unchanged.
+func transformGame(t *testing.T, game *reversi.Game, tr reversi.Transform) *reversi.Game {
+	t.Helper()
+	ret, err := reversi.NewGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret.SetLogger(nil)
+	ret.Start()
+	for _, m := range game.History() {
+		if m.IsPass() {
+			continue
+		}
+		if err := ret.SetStone(m.Color, tr.Position(m.Pos, 8, 8)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ret
+}
+
+func TestBook_AddGame(t *testing.T) {
+	game := randomGame(t, 1)
+	b := New()
+	if err := b.AddGame(game); err != nil {
+		t.Fatal(err)
+	}
+	positions := b.Len()
+	if positions != DefaultMaxPly {
+		t.Errorf("got: %d positions, expected: %d", positions, DefaultMaxPly)
+	}
+
+	testcases := []struct {
+		desc string
+		tr   reversi.Transform
+	}{
+		{desc: "when the same game", tr: reversi.Identity},
+		{desc: "when mirrored on the diagonal", tr: reversi.FlipDiagonal},
+		{desc: "when rotated half a turn", tr: reversi.Rotate180},
+	}
+	for i, tc := range testcases {
+		if err := b.AddGame(transformGame(t, game, tc.tr)); err != nil {
+			t.Fatal(err)
+		}
+		if b.Len() != positions {
+			t.Errorf("%s, got: %d positions, expected: %d", tc.desc, b.Len(), positions)
+		}
+		choices := b.Lookup(reversi.NewBoard(reversi.InitBoard), reversi.Black)
+		if len(choices) != 1 || choices[0].Games != i+2 {
+			t.Errorf("%s, got: %v, expected: one move played %d times", tc.desc, choices, i+2)
+		}
+	}
+
+	// Every move of the mirrored game is found on its own board.
+	mirrored := transformGame(t, game, reversi.FlipDiagonal)
+	replay, _ := reversi.NewGame()
+	replay.SetLogger(nil)
+	replay.Start()
+	for _, m := range mirrored.History()[:DefaultMaxPly] {
+		if m.IsPass() {
+			continue
+		}
+		choices := b.Lookup(replay.Board(), m.Color)
+		found := false
+		for _, c := range choices {
+			if _, err := replay.Board().Apply(m.Color, c.Move); err != nil {
+				t.Errorf("got: illegal book move %v, expected: a legal move", c.Move)
+			}
+			next, _ := replay.Board().Apply(m.Color, c.Move)
+			played, _ := replay.Board().Apply(m.Color, m.Pos)
+			a, _ := next.Canonical()
+			p, _ := played.Canonical()
+			found = found || a.Equal(p)
+		}
+		if !found {
+			t.Errorf("got: %v, expected a move equivalent to %v", choices, m.Pos)
+		}
+		replay.SetStone(m.Color, m.Pos)
+	}
+}
+
+func TestBook_AddGame_unfinished(t *testing.T) {
+	game, err := reversi.ParseTranscript("f5d6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := New().AddGame(game); !errors.Is(err, ErrUnfinished) {
+		t.Errorf("got: %v, expected: %v", err, ErrUnfinished)
+	}
+}
+
+func TestBook_AddTranscript(t *testing.T) {
+	b := New()
+	b.MaxPly = 4
+	transcript := randomGame(t, 2).Transcript()
+	if err := b.AddTranscript(transcript); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 4 {
+		t.Errorf("got: %d positions, expected: 4", b.Len())
+	}
+	if err := b.AddTranscript("f5z9"); err == nil {
+		t.Errorf("got: nil, expected an error for a bad transcript")
+	}
+}
+
+func wthorFile(games [][]byte) []byte {
+	h := make([]byte, 16)
+	binary.LittleEndian.PutUint32(h[4:8], uint32(len(games)))
+	h[12] = 8
+	for _, g := range games {
+		h = append(h, g...)
+	}
+	return h
+}
+
+func wthorRecord(score int, moves []*reversi.Move) []byte {
+	r := make([]byte, 68)
+	r[6] = byte(score)
+	i := 8
+	for _, m := range moves {
+		if !m.IsPass() {
+			r[i] = byte((m.Pos.Y+1)*10 + m.Pos.X + 1)
+			i++
+		}
+	}
+	return r
+}
+
+func TestBook_AddWthor(t *testing.T) {
+	game := randomGame(t, 3)
+	data := wthorFile([][]byte{
+		wthorRecord(64, game.History()),
+		wthorRecord(32, game.History()),
+		wthorRecord(0, game.History()[:10]),
+	})
+	b := New()
+	n, err := b.AddWthor(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("got: %d games, expected: 3", n)
+	}
+	choices := b.Lookup(reversi.NewBoard(reversi.InitBoard), reversi.Black, WithMaxDeviation(1))
+	if len(choices) != 1 || choices[0].Games != 3 || choices[0].WinRate != 0.5 {
+		t.Errorf("got: %v, expected: one move with 3 games won half", choices)
+	}
+
+	if _, err := New().AddWthor(bytes.NewReader(data[:10])); !errors.Is(err, reversi.ErrWthor) {
+		t.Errorf("got: %v, expected: %v", err, reversi.ErrWthor)
+	}
+}
+
+func TestBook_Evaluate(t *testing.T) {
+	b := New()
+	b.MaxPly = 3
+	if err := b.AddGame(randomGame(t, 4)); err != nil {
+		t.Fatal(err)
+	}
+	eng := engine.New(engine.DiscCount{})
+	if err := b.Evaluate(context.Background(), eng, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	start := reversi.NewBoard(reversi.InitBoard)
+	choices := b.Lookup(start, reversi.Black)
+	if len(choices) != 1 || !choices[0].Evaluated {
+		t.Fatalf("got: %v, expected: one evaluated move", choices)
+	}
+	// Every first move leaves black 4 discs to white's 1.
+	if choices[0].Eval != 3 {
+		t.Errorf("got: %d, expected: 3", choices[0].Eval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Evaluate(ctx, eng, 1, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("got: %v, expected: %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,206 @@
+package book
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+// The book file starts with a header:
+//
+//	magic   [4]byte "RVBK"
+//	version uint8
+//	maxPly  uint8
+//	nodes   uint32
+//
+// followed by the positions:
+//
+//	width, height uint8
+//	color         uint8 side to move, 1 black or 2 white
+//	cells         [(width*height+3)/4]byte canonical board, 2 bits a cell
+//	moves         uint16
+//
+// each followed by its moves:
+//
+//	move   uint16 y*width+x on the canonical board
+//	games  uint32
+//	points uint32 half points of the side to move
+//	flags  uint8  1 when evaluated
+//	eval   int32
+//
+// Integers are little-endian, and positions are sorted by hash so that equal
+// books make equal files.
+const (
+	fileMagic   = "RVBK"
+	FileVersion = 1
+)
+
+// WriteTo writes the book in its binary format. Boards wider or higher than
+// 255 squares do not fit in it, and nothing is written when the book has any.
+func (b *Book) WriteTo(w io.Writer) (int64, error) {
+	for _, n := range b.nodes {
+		if n.board.Width > 255 || n.board.Height > 255 {
+			return 0, fmt.Errorf("%w: %dx%d board does not fit in the file format", ErrBook, n.board.Width, n.board.Height)
+		}
+	}
+	bw := &countWriter{w: bufio.NewWriter(w)}
+	bw.write([]byte(fileMagic))
+	bw.write([]byte{FileVersion, byte(max(0, min(b.MaxPly, 255)))})
+	bw.u32(uint32(len(b.nodes)))
+	for _, key := range b.keys() {
+		n := b.nodes[key]
+		bw.write([]byte{byte(n.board.Width), byte(n.board.Height), byte(n.color)})
+		bw.write(packCells(n.board))
+		bw.u16(uint16(len(n.moves)))
+		for _, e := range n.moves {
+			bw.u16(uint16(e.move))
+			bw.u32(uint32(e.games))
+			bw.u32(uint32(e.points))
+			var flags byte
+			if e.evaluated {
+				flags = 1
+			}
+			bw.write([]byte{flags})
+			bw.u32(uint32(int32(e.eval)))
+		}
+	}
+	if bw.err == nil {
+		bw.err = bw.w.Flush()
+	}
+	return bw.n, bw.err
+}
+
+// Read reads a book written by WriteTo.
+func Read(r io.Reader) (*Book, error) {
+	br := &byteReader{r: bufio.NewReader(r)}
+	if magic := br.read(4); br.err == nil && string(magic) != fileMagic {
+		return nil, fmt.Errorf("%w: bad magic %q", ErrBook, magic)
+	}
+	head := br.read(2)
+	if br.err == nil && head[0] != FileVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrBook, head[0])
+	}
+	count := br.u32()
+	if br.err != nil {
+		return nil, br.error("header")
+	}
+
+	b := New()
+	b.MaxPly = int(head[1])
+	for i := 0; i < int(count); i++ {
+		h := br.read(3)
+		if br.err != nil {
+			return nil, br.error(fmt.Sprintf("position %d", i+1))
+		}
+		width, height, color := int(h[0]), int(h[1]), reversi.Color(h[2])
+		if width == 0 || height == 0 || !color.Valid() {
+			return nil, fmt.Errorf("%w: position %d: bad header", ErrBook, i+1)
+		}
+		cells := br.read((width*height + 3) / 4)
+		moves := int(br.u16())
+		if br.err != nil {
+			return nil, br.error(fmt.Sprintf("position %d", i+1))
+		}
+		n := newNode(unpackCells(cells, width, height), color)
+		for j := 0; j < moves; j++ {
+			e := &edge{
+				move:   int(br.u16()),
+				games:  int(br.u32()),
+				points: int(br.u32()),
+			}
+			e.evaluated = br.read(1)[0]&1 != 0
+			e.eval = int(int32(br.u32()))
+			if br.err != nil {
+				return nil, br.error(fmt.Sprintf("position %d move %d", i+1, j+1))
+			}
+			if e.move >= width*height {
+				return nil, fmt.Errorf("%w: position %d move %d: out of bounds", ErrBook, i+1, j+1)
+			}
+			n.moves = append(n.moves, e)
+		}
+		b.nodes[n.board.HashTurn(color)] = n
+	}
+	return b, nil
+}
+
+func packCells(board *reversi.Board) []byte {
+	ret := make([]byte, (board.Width*board.Height+3)/4)
+	for y := 0; y < board.Height; y++ {
+		for x := 0; x < board.Width; x++ {
+			i := y*board.Width + x
+			ret[i/4] |= byte(board.Cell(x, y).State&3) << (uint(i%4) * 2)
+		}
+	}
+	return ret
+}
+
+func unpackCells(cells []byte, width, height int) *reversi.Board {
+	init := make([][]int, height)
+	for y := range init {
+		init[y] = make([]int, width)
+		for x := range init[y] {
+			i := y*width + x
+			init[y][x] = int(cells[i/4]>>(uint(i%4)*2)) & 3
+		}
+	}
+	return reversi.NewBoard(init)
+}
+
+// countWriter writes little-endian values, keeping the first error.
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (w *countWriter) write(p []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	w.err = err
+}
+
+func (w *countWriter) u16(v uint16) {
+	w.write(binary.LittleEndian.AppendUint16(nil, v))
+}
+
+func (w *countWriter) u32(v uint32) {
+	w.write(binary.LittleEndian.AppendUint32(nil, v))
+}
+
+// byteReader reads little-endian values, keeping the first error. After an
+// error it returns zeros.
+type byteReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (r *byteReader) read(n int) []byte {
+	buf := make([]byte, n)
+	if r.err == nil {
+		_, r.err = io.ReadFull(r.r, buf)
+	}
+	return buf
+}
+
+func (r *byteReader) u16() uint16 {
+	return binary.LittleEndian.Uint16(r.read(2))
+}
+
+func (r *byteReader) u32() uint32 {
+	return binary.LittleEndian.Uint32(r.read(4))
+}
+
+// error wraps the read error of the part of the file being read.
+func (r *byteReader) error(part string) error {
+	if errors.Is(r.err, io.EOF) || errors.Is(r.err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %s: truncated", ErrBook, part)
+	}
+	return r.err
+}
@@ -0,0 +1,89 @@
+package book
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+func TestBook_WriteTo(t *testing.T) {
+	b := New()
+	b.MaxPly = 10
+	for seed := int64(1); seed <= 5; seed++ {
+		if err := b.AddGame(randomGame(t, seed)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b.SetEval(reversi.NewBoard(reversi.InitBoard), reversi.Black, &reversi.Position{X: 5, Y: 4}, -7)
+
+	var buf bytes.Buffer
+	n, err := b.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("got: %d bytes, expected: %d", n, buf.Len())
+	}
+	read, err := Read(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Len() != b.Len() || read.MaxPly != b.MaxPly {
+		t.Errorf("got: %d positions up to ply %d, expected: %d up to ply %d", read.Len(), read.MaxPly, b.Len(), b.MaxPly)
+	}
+	var again bytes.Buffer
+	if _, err := read.WriteTo(&again); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again.Bytes(), buf.Bytes()) {
+		t.Errorf("got: a different file after reading it back")
+	}
+
+	choices := read.Lookup(reversi.NewBoard(reversi.InitBoard), reversi.Black, WithMaxDeviation(1))
+	if len(choices) != 1 || choices[0].Games != 5 || !choices[0].Evaluated || choices[0].Eval != -7 {
+		t.Errorf("got: %v, expected: one move of 5 games evaluated -7", choices)
+	}
+}
+
+func TestBook_WriteTo_tooLarge(t *testing.T) {
+	b := New()
+	b.SetEval(reversi.NewBoard([][]int{make([]int, 256)}), reversi.Black, &reversi.Position{X: 0, Y: 0}, 0)
+	var buf bytes.Buffer
+	if n, err := b.WriteTo(&buf); !errors.Is(err, ErrBook) || n != 0 || buf.Len() != 0 {
+		t.Errorf("got: %d bytes, %v, expected: nothing written, %v", n, err, ErrBook)
+	}
+}
+
+func TestRead_errors(t *testing.T) {
+	b := New()
+	if err := b.AddGame(randomGame(t, 1)); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := b.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	badVersion := append([]byte{}, data...)
+	badVersion[4] = 99
+	badColor := append([]byte{}, data...)
+	badColor[12] = 3
+	testcases := []struct {
+		desc string
+		data []byte
+	}{
+		{desc: "when empty", data: nil},
+		{desc: "when bad magic", data: append([]byte("XXXX"), data[4:]...)},
+		{desc: "when bad version", data: badVersion},
+		{desc: "when bad color", data: badColor},
+		{desc: "when truncated", data: data[:len(data)-1]},
+	}
+	for _, tc := range testcases {
+		if _, err := Read(bytes.NewReader(tc.data)); !errors.Is(err, ErrBook) {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, err, ErrBook)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package book
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+// Choice is a book move from a position, with the statistics behind it.
+type Choice struct {
+	Move    *reversi.Position
+	Games   int
+	WinRate float64 // share of the games won by the side to move, draws counting half
+	// Eval is the evaluation of the move for the side to move, when
+	// Evaluated is set.
+	Eval      int
+	Evaluated bool
+	// Weight is the probability of playing the move. The weights of the
+	// choices of a lookup add up to 1.
+	Weight float64
+}
+
+// LookupOption tunes which book moves are offered and how they are weighted.
+type LookupOption func(*lookupConfig)
+
+type lookupConfig struct {
+	minGames     int
+	maxDeviation float64
+	maxEvalLoss  int
+	evalLoss     bool
+	temperature  float64
+}
+
+// WithMinGames offers only moves played in at least n games. The default is
+// 1.
+func WithMinGames(n int) LookupOption {
+	return func(c *lookupConfig) {
+		c.minGames = n
+	}
+}
+
+// WithMaxDeviation drops moves whose win rate is more than d below the best
+// one. The default is 0.1; 0 keeps only the moves with the best win rate.
+func WithMaxDeviation(d float64) LookupOption {
+	return func(c *lookupConfig) {
+		c.maxDeviation = d
+	}
+}
+
+// WithMaxEvalLoss drops evaluated moves whose evaluation is more than n below
+// the best evaluated move. By default evaluations are not looked at.
+func WithMaxEvalLoss(n int) LookupOption {
+	return func(c *lookupConfig) {
+		c.maxEvalLoss, c.evalLoss = n, true
+	}
+}
+
+// WithTemperature sets how much weaker moves are played. A move weighs its
+// number of games times exp((winRate-best)/t), so a high temperature follows
+// how often moves were played and a low one favours the best win rate. The
+// default is 0.05; 0 plays the best win rate only.
+func WithTemperature(t float64) LookupOption {
+	return func(c *lookupConfig) {
+		c.temperature = t
+	}
+}
+
+// Lookup returns the book moves of board with color to move, highest weight
+// first, in the coordinates of board. It returns nil when the position is
+// not in the book or no move passes the options.
+func (b *Book) Lookup(board *reversi.Board, color reversi.Color, opts ...LookupOption) []Choice {
+	c := &lookupConfig{minGames: 1, maxDeviation: 0.1, temperature: 0.05}
+	for _, opt := range opts {
+		opt(c)
+	}
+	n, t := b.node(board, color, false)
+	if n == nil {
+		return nil
+	}
+
+	var ret []Choice
+	for _, e := range n.moves {
+		if e.games < c.minGames || e.games == 0 {
+			continue
+		}
+		ret = append(ret, Choice{
+			Move:      n.position(t, e.move),
+			Games:     e.games,
+			WinRate:   float64(e.points) / float64(2*e.games),
+			Eval:      e.eval,
+			Evaluated: e.evaluated,
+		})
+	}
+	if c.evalLoss {
+		ret = filterEval(ret, c.maxEvalLoss)
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+
+	best := 0.0
+	for _, ch := range ret {
+		best = math.Max(best, ch.WinRate)
+	}
+	kept := ret[:0]
+	total := 0.0
+	for _, ch := range ret {
+		if best-ch.WinRate > c.maxDeviation {
+			continue
+		}
+		if c.temperature > 0 {
+			ch.Weight = float64(ch.Games) * math.Exp((ch.WinRate-best)/c.temperature)
+		} else if ch.WinRate == best {
+			ch.Weight = 1
+		}
+		if ch.Weight == 0 {
+			continue
+		}
+		total += ch.Weight
+		kept = append(kept, ch)
+	}
+	ret = kept
+	for i := range ret {
+		ret[i].Weight /= total
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Weight != ret[j].Weight {
+			return ret[i].Weight > ret[j].Weight
+		}
+		return ret[i].Games > ret[j].Games
+	})
+	return ret
+}
+
+// filterEval drops the evaluated choices more than loss below the best
+// evaluated one.
+func filterEval(choices []Choice, loss int) []Choice {
+	best, found := 0, false
+	for _, ch := range choices {
+		if ch.Evaluated && (!found || ch.Eval > best) {
+			best, found = ch.Eval, true
+		}
+	}
+	ret := choices[:0]
+	for _, ch := range choices {
+		if !ch.Evaluated || best-ch.Eval <= loss {
+			ret = append(ret, ch)
+		}
+	}
+	return ret
+}
+
+// Sample picks one of choices at random according to their weights. It
+// returns nil when there is no choice.
+func Sample(choices []Choice, rng *rand.Rand) *reversi.Position {
+	if len(choices) == 0 {
+		return nil
+	}
+	r := rng.Float64()
+	for _, ch := range choices {
+		r -= ch.Weight
+		if r < 0 {
+			return ch.Move
+		}
+	}
+	return choices[len(choices)-1].Move
+}
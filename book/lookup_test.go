@@ -0,0 +1,120 @@
+package book
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+// statsBook returns a book with statistics for three moves of black after
+// f5f6, a position without symmetries.
+func statsBook(t *testing.T) (*Book, *reversi.Board) {
+	t.Helper()
+	game, err := reversi.ParseTranscript("f5f6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := game.Board()
+	b := New()
+	for _, s := range []struct {
+		move   string
+		games  int
+		points int
+		eval   int
+	}{
+		{move: "e6", games: 10, points: 12, eval: 2},
+		{move: "d3", games: 10, points: 10, eval: 6},
+		{move: "c5", games: 4, points: 5},
+	} {
+		pos, err := reversi.ParsePosition(s.move)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, tr := b.node(board, reversi.Black, true)
+		e := n.edge(tr, pos, true)
+		e.games, e.points = s.games, s.points
+		if s.eval != 0 {
+			e.eval, e.evaluated = s.eval, true
+		}
+	}
+	return b, board
+}
+
+func TestBook_Lookup(t *testing.T) {
+	b, board := statsBook(t)
+	testcases := []struct {
+		desc     string
+		opts     []LookupOption
+		expected []string
+	}{
+		{desc: "when default", expected: []string{"e6", "c5"}},
+		{desc: "when wider deviation", opts: []LookupOption{WithMaxDeviation(0.6)}, expected: []string{"e6", "c5", "d3"}},
+		{desc: "when more games needed", opts: []LookupOption{WithMinGames(5), WithMaxDeviation(0.05)}, expected: []string{"e6"}},
+		{desc: "when no deviation", opts: []LookupOption{WithMaxDeviation(0)}, expected: []string{"c5"}},
+		{desc: "when evaluations filter", opts: []LookupOption{WithMaxDeviation(1), WithMaxEvalLoss(2)}, expected: []string{"c5", "d3"}},
+		{desc: "when high temperature", opts: []LookupOption{WithMaxDeviation(1), WithTemperature(1000)}, expected: []string{"e6", "d3", "c5"}},
+	}
+	for _, tc := range testcases {
+		choices := b.Lookup(board, reversi.Black, tc.opts...)
+		var got []string
+		total := 0.0
+		for _, c := range choices {
+			got = append(got, c.Move.String())
+			total += c.Weight
+		}
+		if len(got) != len(tc.expected) {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, got, tc.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.expected[i] {
+				t.Errorf("%s, got: %v, expected: %v", tc.desc, got, tc.expected)
+				break
+			}
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("%s, got: weights adding up to %v, expected: 1", tc.desc, total)
+		}
+	}
+
+	if choices := b.Lookup(board, reversi.White); choices != nil {
+		t.Errorf("got: %v, expected: nil for the other side to move", choices)
+	}
+}
+
+func TestBook_Lookup_symmetric(t *testing.T) {
+	b, board := statsBook(t)
+	expected := b.Lookup(board, reversi.Black)
+	for _, tr := range reversi.Transforms {
+		choices := b.Lookup(board.Transform(tr), reversi.Black)
+		if len(choices) != len(expected) {
+			t.Fatalf("%s, got: %v, expected: %v", tr, choices, expected)
+		}
+		for i, c := range choices {
+			if want := tr.Position(expected[i].Move, 8, 8); *c.Move != *want || c.Games != expected[i].Games {
+				t.Errorf("%s, got: %v, expected: %v", tr, c.Move, want)
+			}
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	b, board := statsBook(t)
+	choices := b.Lookup(board, reversi.Black, WithMaxDeviation(1), WithTemperature(1000))
+	rng := rand.New(rand.NewSource(1))
+	counts := map[string]int{}
+	for i := 0; i < 2400; i++ {
+		counts[Sample(choices, rng).String()]++
+	}
+	for _, c := range choices {
+		got := float64(counts[c.Move.String()]) / 2400
+		if math.Abs(got-c.Weight) > 0.05 {
+			t.Errorf("%v, got: %v, expected: %v", c.Move, got, c.Weight)
+		}
+	}
+	if Sample(nil, rng) != nil {
+		t.Errorf("got: a move, expected: nil without choices")
+	}
+}
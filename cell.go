@@ -1,20 +1,65 @@
 package reversi
 
+import (
+	"fmt"
+)
+
 type Cell struct {
-	X     int `json:"x"`
-	Y     int `json:"y"`
-	State int `json:"state"`
+	X     int   `json:"x"`
+	Y     int   `json:"y"`
+	State Color `json:"state"`
 }
 
-type CellState int
+// Color is the content of a cell: no disc, a disc of one of the players, or
+// a wall that no disc can be placed on or flipped across.
+type Color int
 
 const (
-	None CellState = iota
+	None Color = iota
 	Black
 	White
+	Wall
 )
 
-func (c *Cell) Update(color int) {
+// CellState is the former name of Color.
+//
+// Deprecated: use Color.
+type CellState = Color
+
+// Valid reports whether c is a player color.
+func (c Color) Valid() bool {
+	return c == Black || c == White
+}
+
+// Opponent returns the other player's color, or None when c is not a player
+// color.
+func (c Color) Opponent() Color {
+	switch c {
+	case Black:
+		return White
+	case White:
+		return Black
+	default:
+		return None
+	}
+}
+
+func (c Color) String() string {
+	switch c {
+	case None:
+		return "none"
+	case Black:
+		return "black"
+	case White:
+		return "white"
+	case Wall:
+		return "wall"
+	default:
+		return fmt.Sprintf("Color(%d)", int(c))
+	}
+}
+
+func (c *Cell) Update(color Color) {
 	c.State = color
 }
 
@@ -0,0 +1,79 @@
+package reversi
+
+// Clone returns a deep copy of the board. Moves played on the copy do not
+// affect b.
+func (b *Board) Clone() *Board {
+	ret := &Board{Width: b.Width, Height: b.Height, hash: b.hash, logger: b.logger}
+	if b.bits != nil {
+		bits := *b.bits
+		ret.bits = &bits
+		return ret
+	}
+	ret.board = make([][]*Cell, len(b.board))
+	for i, line := range b.board {
+		cLine := make([]*Cell, len(line))
+		for j, cell := range line {
+			c := *cell
+			cLine[j] = &c
+		}
+		ret.board[i] = cLine
+	}
+	return ret
+}
+
+// Equal reports whether b and other have the same size and the same state in
+// every cell, regardless of how they are stored.
+func (b *Board) Equal(other *Board) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+	if b.Width != other.Width || b.Height != other.Height {
+		return false
+	}
+	if b.bits != nil && other.bits != nil {
+		return *b.bits == *other.bits
+	}
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			if b.Cell(j, i).State != other.Cell(j, i).State {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Snapshot returns a copy of the cells of the board, like GetBoard, but as
+// values rather than pointers.
+func (b *Board) Snapshot() [][]Cell {
+	ret := make([][]Cell, b.Height)
+	for i := range ret {
+		line := make([]Cell, b.Width)
+		for j := range line {
+			line[j] = *b.Cell(j, i)
+		}
+		ret[i] = line
+	}
+	return ret
+}
+
+// Clone returns a deep copy of the game, including its history, so the copy
+// can be played on without affecting game.
+func (game *Game) Clone() *Game {
+	ret := *game
+	ret.board = game.board.Clone()
+	ret.history = make([]*Move, len(game.history))
+	copy(ret.history, game.history)
+	return &ret
+}
+
+// Snapshot returns a copy of the cells of the game board.
+func (game *Game) Snapshot() [][]Cell {
+	return game.board.Snapshot()
+}
+
+// Board returns a copy of the game board, for analysing the position without
+// affecting the game.
+func (game *Game) Board() *Board {
+	return game.board.Clone()
+}
@@ -5,6 +5,17 @@ type Direction struct {
 	dy int
 }
 
+var directions = []*Direction{
+	{dx: 0, dy: -1},  // top
+	{dx: 1, dy: -1},  // top right
+	{dx: 1, dy: 0},   // right
+	{dx: 1, dy: 1},   // bottom right
+	{dx: 0, dy: 1},   // bottom
+	{dx: -1, dy: 1},  // bottom left
+	{dx: -1, dy: 0},  // left
+	{dx: -1, dy: -1}, // top left
+}
+
 func (d *Direction) Next(x, y int) (int, int) {
 	dx := x + d.dx
 	dy := y + d.dy
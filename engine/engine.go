@@ -0,0 +1,235 @@
+// Package engine searches reversi positions for the best move.
+//
+// The search is a negamax with alpha-beta pruning over reversi.Board, so it
+// follows the standard rules whatever variant a game is played under.
+package engine
+
+import (
+	"context"
+	"sort"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+// WinScore is added to the final disc difference of a finished game, so that
+// any win scores above any evaluation.
+const WinScore = 1 << 20
+
+// Result is the outcome of a search.
+type Result struct {
+	// Move is the best move, or nil when the side to move has to pass or the
+	// game is over.
+	Move *reversi.Position
+	// Score is the value of the position for the side to move.
+	Score int
+	// PV is the principal variation starting with Move. A nil entry is a pass.
+	PV    []*reversi.Position
+	Depth int
+	Nodes int64
+}
+
+// Engine searches positions with an Evaluator. An Engine is not safe for
+// concurrent use.
+type Engine struct {
+	Eval Evaluator
+	// Progress, when set, is called by Think after every completed iteration.
+	Progress func(Progress)
+	// TT, when set, stores search results so that positions reached again,
+	// in this search or a later one, are not searched twice. Principal
+	// variations may then stop short where a stored result was used.
+	TT *TranspositionTable
+
+	nodes   int64
+	ctx     context.Context
+	aborted bool
+}
+
+// New returns an engine scoring positions with eval, or Default when eval is
+// nil.
+func New(eval Evaluator) *Engine {
+	if eval == nil {
+		eval = Default
+	}
+	return &Engine{Eval: eval}
+}
+
+// Search looks depth plies ahead from b with color to move. Passes do not
+// count as plies.
+func (e *Engine) Search(b *reversi.Board, color reversi.Color, depth int) Result {
+	e.start(nil)
+	return e.root(b, color, depth, nil)
+}
+
+// start resets the engine for a new search.
+func (e *Engine) start(ctx context.Context) {
+	e.nodes = 0
+	e.ctx, e.aborted = ctx, false
+	if e.TT != nil {
+		e.TT.NewSearch()
+	}
+}
+
+// root searches b to depth, trying hint first when it is a legal move.
+func (e *Engine) root(b *reversi.Board, color reversi.Color, depth int, hint *reversi.Position) Result {
+	var pv []*reversi.Position
+	var score int
+	if depth <= 0 || b.Mobility(color) == 0 {
+		score = e.negamax(b, color, depth, -WinScore*2, WinScore*2, &pv)
+	} else {
+		children := e.children(b, color)
+		if hint != nil {
+			promote(children, hint.Y*b.Width+hint.X, b.Width)
+		}
+		alpha := -WinScore * 2
+		var line []*reversi.Position
+		for _, c := range children {
+			s := -e.negamax(c.board, color.Opponent(), depth-1, -WinScore*2, -alpha, &line)
+			if e.aborted {
+				break
+			}
+			if s > alpha {
+				alpha = s
+				pv = append(append(pv[:0], c.move), line...)
+			}
+		}
+		score = alpha
+	}
+	ret := Result{Score: score, PV: pv, Depth: depth, Nodes: e.nodes}
+	if len(pv) > 0 {
+		ret.Move = pv[0]
+	}
+	return ret
+}
+
+func (e *Engine) negamax(b *reversi.Board, color reversi.Color, depth, alpha, beta int, pv *[]*reversi.Position) int {
+	e.nodes++
+	*pv = (*pv)[:0]
+	if e.stopped() {
+		return 0
+	}
+	opponent := color.Opponent()
+	if b.Mobility(color) == 0 {
+		if b.Mobility(opponent) == 0 {
+			return Final(b, color)
+		}
+		var line []*reversi.Position
+		score := -e.negamax(b, opponent, depth, -beta, -alpha, &line)
+		*pv = append(append(*pv, nil), line...)
+		return score
+	}
+	if depth <= 0 {
+		return e.Eval.Evaluate(b, color)
+	}
+
+	key := b.HashTurn(color)
+	ttMove := NoMove
+	if e.TT != nil {
+		if entry, ok := e.TT.Probe(key); ok {
+			if score, ok := entry.cutoff(depth, &alpha, &beta); ok {
+				return score
+			}
+			ttMove = int(entry.Move)
+		}
+	}
+
+	alpha0 := alpha
+	children := e.children(b, color)
+	promote(children, ttMove, b.Width)
+	best := -WinScore * 2
+	var bestMove *reversi.Position
+	var line []*reversi.Position
+	for _, c := range children {
+		score := -e.negamax(c.board, opponent, depth-1, -beta, -alpha, &line)
+		if e.aborted {
+			return 0
+		}
+		if score > best {
+			best, bestMove = score, c.move
+		}
+		if score > alpha {
+			alpha = score
+			*pv = append(append((*pv)[:0], c.move), line...)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	if e.TT != nil {
+		e.TT.Store(key, Entry{
+			Score: int32(best),
+			Depth: int16(depth),
+			Move:  int16(bestMove.Y*b.Width + bestMove.X),
+			Bound: bound(best, alpha0, beta),
+		})
+	}
+	return best
+}
+
+// promote moves the child playing the move with index y*width+x to the
+// front, keeping the order of the others.
+func promote(children []child, index, width int) {
+	if index < 0 {
+		return
+	}
+	for i, c := range children {
+		if c.move.Y*width+c.move.X == index {
+			copy(children[1:i+1], children[:i])
+			children[0] = c
+			return
+		}
+	}
+}
+
+// stopped reports whether the context of Think has ended. It only looks at
+// the context every few thousand nodes.
+func (e *Engine) stopped() bool {
+	if e.aborted {
+		return true
+	}
+	if e.ctx == nil || e.nodes%4096 != 0 {
+		return false
+	}
+	if e.ctx.Err() != nil {
+		e.aborted = true
+	}
+	return e.aborted
+}
+
+type child struct {
+	move  *reversi.Position
+	board *reversi.Board
+	key   int
+}
+
+// children plays every legal move of color and orders the resulting
+// positions, best first according to the evaluator.
+func (e *Engine) children(b *reversi.Board, color reversi.Color) []child {
+	moves := b.ListAllocatablePositions(color)
+	ret := make([]child, 0, len(moves))
+	opponent := color.Opponent()
+	for _, m := range moves {
+		next, err := b.Apply(color, m)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, child{move: m, board: next, key: -e.Eval.Evaluate(next, opponent)})
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].key > ret[j].key
+	})
+	return ret
+}
+
+// Final scores a finished game for color: the disc difference, pushed above
+// or below any evaluation when the game is won or lost.
+func Final(b *reversi.Board, color reversi.Color) int {
+	diff := b.Count(color) - b.Count(color.Opponent())
+	switch {
+	case diff > 0:
+		return WinScore + diff
+	case diff < 0:
+		return -WinScore + diff
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"testing"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+// minimax is a plain search without pruning to check the engine against.
+func minimax(eval Evaluator, b *reversi.Board, color reversi.Color, depth int) int {
+	opponent := color.Opponent()
+	moves := b.ListAllocatablePositions(color)
+	if len(moves) == 0 {
+		if b.Mobility(opponent) == 0 {
+			return Final(b, color)
+		}
+		return -minimax(eval, b, opponent, depth)
+	}
+	if depth == 0 {
+		return eval.Evaluate(b, color)
+	}
+	best := -WinScore * 2
+	for _, m := range moves {
+		next, _ := b.Apply(color, m)
+		if score := -minimax(eval, next, opponent, depth-1); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+func position(t *testing.T, transcript string) (*reversi.Board, reversi.Color) {
+	t.Helper()
+	game, err := reversi.ParseTranscript(transcript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return game.Board(), game.GameState.Turn()
+}
+
+func TestEngine_Search(t *testing.T) {
+	testcases := []struct {
+		desc       string
+		transcript string
+		eval       Evaluator
+		depth      int
+	}{
+		{desc: "when opening with disc count", transcript: "", eval: DiscCount{}, depth: 4},
+		{desc: "when midgame with default", transcript: "f5d6c3d3c4f4c5b3c2e6c6b4", eval: Default, depth: 4},
+		{desc: "when midgame with mobility", transcript: "f5f6e6f4e3c5c4e7", eval: Mobility{}, depth: 3},
+		{desc: "when depth is zero", transcript: "f5d6", eval: Default, depth: 0},
+	}
+	for _, tc := range testcases {
+		b, color := position(t, tc.transcript)
+		actual := New(tc.eval).Search(b, color, tc.depth)
+		expected := minimax(tc.eval, b, color, tc.depth)
+		if actual.Score != expected {
+			t.Errorf("%s, got: %d, expected: %d", tc.desc, actual.Score, expected)
+		}
+		if tc.depth > 0 && (actual.Move == nil || len(actual.PV) != tc.depth) {
+			t.Errorf("%s, got pv: %v, expected %d moves", tc.desc, actual.PV, tc.depth)
+		}
+
+		// The principal variation must be playable and end where the score
+		// was taken.
+		cur, c := b, color
+		for _, m := range actual.PV {
+			if m == nil {
+				c = c.Opponent()
+				continue
+			}
+			next, err := cur.Apply(c, m)
+			if err != nil {
+				t.Fatalf("%s, pv %v: %v", tc.desc, actual.PV, err)
+			}
+			cur, c = next, c.Opponent()
+		}
+		leaf := tc.eval.Evaluate(cur, c)
+		if c != color {
+			leaf = -leaf
+		}
+		if tc.depth > 0 && leaf != actual.Score {
+			t.Errorf("%s, pv leaf got: %d, expected: %d", tc.desc, leaf, actual.Score)
+		}
+	}
+}
+
+func TestEngine_Search_corner(t *testing.T) {
+	b := reversi.NewBoard([][]int{
+		{0, 2, 2, 1, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 2, 1, 0, 0, 0},
+		{0, 0, 0, 1, 2, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+	})
+	actual := New(WeightedSquares{}).Search(b, reversi.Black, 1)
+	expected := reversi.Position{X: 0, Y: 0}
+	if actual.Move == nil || *actual.Move != expected {
+		t.Errorf("got: %v, expected: %v", actual.Move, expected)
+	}
+}
+
+func TestEngine_Search_finished(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		board    [][]int
+		color    reversi.Color
+		expected int
+		pv       int
+	}{
+		{
+			desc:     "when the game is over",
+			board:    [][]int{{1, 1, 1, 1}, {1, 1, 1, 1}, {2, 2, 2, 2}, {1, 1, 1, 1}},
+			color:    reversi.White,
+			expected: -WinScore - 8,
+		},
+		{
+			desc:     "when the side to move must pass",
+			board:    [][]int{{0, 2, 1, 1}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}},
+			color:    reversi.White,
+			expected: -WinScore - 4,
+			pv:       2,
+		},
+	}
+	for _, tc := range testcases {
+		actual := New(DiscCount{}).Search(reversi.NewBoard(tc.board), tc.color, 3)
+		if actual.Score != tc.expected || actual.Move != nil || len(actual.PV) != tc.pv {
+			t.Errorf("%s, got: %d %v, expected: %d", tc.desc, actual.Score, actual.PV, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package engine
+
+import (
+	reversi "github.com/myoan/go-reversi"
+)
+
+// Evaluator scores a position from the point of view of color. Higher is
+// better for color, and the score for the opponent should be its negation.
+type Evaluator interface {
+	Evaluate(b *reversi.Board, color reversi.Color) int
+}
+
+// EvaluatorFunc adapts a function to the Evaluator interface.
+type EvaluatorFunc func(b *reversi.Board, color reversi.Color) int
+
+func (f EvaluatorFunc) Evaluate(b *reversi.Board, color reversi.Color) int {
+	return f(b, color)
+}
+
+// DiscCount scores the difference in discs.
+type DiscCount struct{}
+
+func (DiscCount) Evaluate(b *reversi.Board, color reversi.Color) int {
+	return b.Count(color) - b.Count(color.Opponent())
+}
+
+// Mobility scores the difference in the number of legal moves.
+type Mobility struct{}
+
+func (Mobility) Evaluate(b *reversi.Board, color reversi.Color) int {
+	return b.Mobility(color) - b.Mobility(color.Opponent())
+}
+
+// Corners scores the difference in corners held.
+type Corners struct{}
+
+func (Corners) Evaluate(b *reversi.Board, color reversi.Color) int {
+	ret := 0
+	for _, y := range []int{0, b.Height - 1} {
+		for _, x := range []int{0, b.Width - 1} {
+			switch b.Cell(x, y).State {
+			case color:
+				ret++
+			case color.Opponent():
+				ret--
+			}
+		}
+	}
+	return ret
+}
+
+// WeightedSquares sums the weight of every square held by color, minus those
+// held by the opponent. Weights is indexed by row then column; when its size
+// does not match the board, SquareWeights for the board size is used.
+type WeightedSquares struct {
+	Weights [][]int
+}
+
+func (w WeightedSquares) Evaluate(b *reversi.Board, color reversi.Color) int {
+	if b.Width == 0 || b.Height == 0 {
+		return 0
+	}
+	weights := w.Weights
+	if !fits(weights, b.Width, b.Height) {
+		weights = squareWeights(b.Width, b.Height)
+	}
+	opponent := color.Opponent()
+	ret := 0
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			switch b.Cell(j, i).State {
+			case color:
+				ret += weights[i][j]
+			case opponent:
+				ret -= weights[i][j]
+			}
+		}
+	}
+	return ret
+}
+
+// fits reports whether weights has height rows of width columns each.
+func fits(weights [][]int, width, height int) bool {
+	if len(weights) != height {
+		return false
+	}
+	for _, row := range weights {
+		if len(row) != width {
+			return false
+		}
+	}
+	return true
+}
+
+// SquareWeights returns the usual table of square values for a width x
+// height board: corners are worth most, the squares next to them least, and
+// edges more than the centre. For 8x8 it is the classic table.
+func SquareWeights(width, height int) [][]int {
+	ret := make([][]int, height)
+	for i := range ret {
+		ret[i] = make([]int, width)
+		for j := range ret[i] {
+			ret[i][j] = squareWeight(min(j, width-1-j), min(i, height-1-i))
+		}
+	}
+	return ret
+}
+
+var classicWeights = SquareWeights(8, 8)
+
+func squareWeights(width, height int) [][]int {
+	if width == 8 && height == 8 {
+		return classicWeights
+	}
+	return SquareWeights(width, height)
+}
+
+// squareWeight values a square dx and dy cells away from the nearest
+// vertical and horizontal edges.
+func squareWeight(dx, dy int) int {
+	near, far := min(dx, dy), max(dx, dy)
+	switch {
+	case near == 0 && far == 0:
+		return 100
+	case near == 0 && far == 1:
+		return -20
+	case near == 0 && far == 2:
+		return 10
+	case near == 0:
+		return 5
+	case near == 1 && far == 1:
+		return -50
+	case near == 1:
+		return -2
+	default:
+		return -1
+	}
+}
+
+// Term is an evaluator with the weight it has in a Mix.
+type Term struct {
+	Eval   Evaluator
+	Weight int
+}
+
+// Mix sums the weighted scores of its terms.
+type Mix []Term
+
+func (m Mix) Evaluate(b *reversi.Board, color reversi.Color) int {
+	ret := 0
+	for _, t := range m {
+		ret += t.Weight * t.Eval.Evaluate(b, color)
+	}
+	return ret
+}
+
+// Default is the evaluator used when none is given: square weights plus
+// mobility.
+var Default Evaluator = Mix{
+	{Eval: WeightedSquares{}, Weight: 1},
+	{Eval: Mobility{}, Weight: 8},
+}
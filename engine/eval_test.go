@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"testing"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+func TestEvaluators(t *testing.T) {
+	b := reversi.NewBoard([][]int{
+		{1, 2, 0, 0},
+		{0, 2, 2, 0},
+		{0, 1, 2, 0},
+		{0, 0, 0, 1},
+	})
+	testcases := []struct {
+		desc     string
+		eval     Evaluator
+		expected int
+	}{
+		{desc: "when disc count", eval: DiscCount{}, expected: -1},
+		{desc: "when mobility", eval: Mobility{}, expected: b.Mobility(reversi.Black) - b.Mobility(reversi.White)},
+		{desc: "when corners", eval: Corners{}, expected: 2},
+		{desc: "when weighted squares", eval: WeightedSquares{}, expected: 100 - 50 + 100 - (-20 - 50 - 50 - 50)},
+		{desc: "when mix", eval: Mix{{Eval: DiscCount{}, Weight: 3}, {Eval: Corners{}, Weight: 2}}, expected: 1},
+		{desc: "when func", eval: EvaluatorFunc(func(*reversi.Board, reversi.Color) int { return 7 }), expected: 7},
+	}
+	for _, tc := range testcases {
+		if actual := tc.eval.Evaluate(b, reversi.Black); actual != tc.expected {
+			t.Errorf("%s, got: %d, expected: %d", tc.desc, actual, tc.expected)
+		}
+	}
+}
+
+func TestWeightedSquares_shape(t *testing.T) {
+	b := reversi.NewBoard(reversi.InitBoard)
+	testcases := []struct {
+		desc     string
+		board    *reversi.Board
+		weights  [][]int
+		expected int
+	}{
+		{desc: "when empty board", board: &reversi.Board{}, expected: 0},
+		{desc: "when empty board with weights", board: &reversi.Board{}, weights: [][]int{{1}}, expected: 0},
+		{desc: "when ragged weights", board: b, weights: append(SquareWeights(8, 7), []int{1}), expected: 0},
+	}
+	for _, tc := range testcases {
+		if actual := (WeightedSquares{Weights: tc.weights}).Evaluate(tc.board, reversi.Black); actual != tc.expected {
+			t.Errorf("%s, got: %d, expected: %d", tc.desc, actual, tc.expected)
+		}
+	}
+}
+
+func TestSquareWeights(t *testing.T) {
+	expected := [][]int{
+		{100, -20, 10, 5, 5, 10, -20, 100},
+		{-20, -50, -2, -2, -2, -2, -50, -20},
+		{10, -2, -1, -1, -1, -1, -2, 10},
+		{5, -2, -1, -1, -1, -1, -2, 5},
+		{5, -2, -1, -1, -1, -1, -2, 5},
+		{10, -2, -1, -1, -1, -1, -2, 10},
+		{-20, -50, -2, -2, -2, -2, -50, -20},
+		{100, -20, 10, 5, 5, 10, -20, 100},
+	}
+	actual := SquareWeights(8, 8)
+	for i := range expected {
+		for j := range expected[i] {
+			if actual[i][j] != expected[i][j] {
+				t.Errorf("(%d, %d), got: %d, expected: %d", j, i, actual[i][j], expected[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package engine
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+// MCTS is a Monte Carlo tree search player using UCT. It plays through
+// reversi.Game, so it follows the game's board size and rules without an
+// evaluator. An MCTS is not safe for concurrent use.
+type MCTS struct {
+	// Playouts is the number of playouts per search. With a Time budget it
+	// is an upper bound, and 0 means no bound. Without either, 1000
+	// playouts are run.
+	Playouts int
+	// Exploration is the UCT exploration constant. 0 means sqrt(2).
+	Exploration float64
+	// Time is the budget for a search. 0 means no budget.
+	Time time.Duration
+	// Reuse keeps the tree between searches, so that the subtree of the moves
+	// played since the last search is kept.
+	Reuse bool
+	// Parallel grows that many independent trees in parallel and adds up
+	// their root statistics. Values below 2 grow a single tree.
+	Parallel int
+	// Seed seeds the random playouts. 0 seeds from the clock.
+	Seed int64
+
+	trees   []*mctsNode
+	history []*reversi.Move
+}
+
+// MoveStats is the search statistics of a move at the root.
+type MoveStats struct {
+	Move    *reversi.Position
+	Visits  int
+	WinRate float64 // share of playouts won by the side to move, draws counting half
+}
+
+// MCTSResult is the outcome of an MCTS search. Stats is sorted by visits,
+// most visited first, and Move is the most visited move.
+type MCTSResult struct {
+	Move     *reversi.Position
+	Stats    []MoveStats
+	Playouts int
+}
+
+type mctsNode struct {
+	move     *reversi.Position
+	color    reversi.Color // the player who made move
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []*reversi.Position
+	visits   int
+	wins     float64 // playouts won by color, draws counting half
+}
+
+// Search runs playouts from the current position of game and returns the
+// statistics of the moves of the side to move. It returns
+// reversi.ErrGameFinished when the game is over.
+func (m *MCTS) Search(ctx context.Context, game *reversi.Game) (MCTSResult, error) {
+	color := game.GameState.Turn()
+	if !color.Valid() {
+		return MCTSResult{}, reversi.ErrGameFinished
+	}
+	root := game.Clone()
+	root.SetLogger(nil)
+
+	if m.Time > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.Time)
+		defer cancel()
+	}
+	playouts := m.Playouts
+	if playouts <= 0 && m.Time <= 0 {
+		playouts = 1000
+	}
+	workers := m.Parallel
+	if workers < 2 {
+		workers = 1
+	}
+	if playouts > 0 && workers > playouts {
+		// A worker without playouts of its own would run without a bound.
+		workers = playouts
+	}
+	seed := m.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	trees := m.reuse(root, workers)
+	counts := make([]int, workers)
+	var wg sync.WaitGroup
+	for i := range trees {
+		limit := 0
+		if playouts > 0 {
+			limit = playouts / workers
+			if i < playouts%workers {
+				limit++
+			}
+		}
+		wg.Add(1)
+		go func(i, limit int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed + int64(i)))
+			for limit == 0 || counts[i] < limit {
+				if ctx.Err() != nil {
+					break
+				}
+				m.playout(trees[i], root, rng)
+				counts[i]++
+			}
+		}(i, limit)
+	}
+	wg.Wait()
+
+	if m.Reuse {
+		m.trees, m.history = trees, root.History()
+	} else {
+		m.trees, m.history = nil, nil
+	}
+	ret := MCTSResult{Stats: rootStats(trees)}
+	for _, n := range counts {
+		ret.Playouts += n
+	}
+	if len(ret.Stats) > 0 {
+		ret.Move = ret.Stats[0].Move
+	}
+	return ret, nil
+}
+
+// reuse returns the trees to grow from game: the subtrees of the previous
+// search reached by the moves played since, or new trees.
+func (m *MCTS) reuse(game *reversi.Game, workers int) []*mctsNode {
+	ret := make([]*mctsNode, workers)
+	played, ok := movesSince(m.history, game.History())
+	for i := range ret {
+		if ok && i < len(m.trees) {
+			if n := m.trees[i].descend(played); n != nil {
+				n.parent = nil
+				ret[i] = n
+				continue
+			}
+		}
+		ret[i] = &mctsNode{color: game.GameState.Turn().Opponent(), untried: game.ListAllocatablePositions(game.GameState.Turn())}
+	}
+	return ret
+}
+
+// movesSince returns the moves of now played after before, when now
+// continues before.
+func movesSince(before, now []*reversi.Move) ([]*reversi.Position, bool) {
+	if before == nil || len(now) < len(before) {
+		return nil, false
+	}
+	for i, m := range before {
+		if !sameMove(m, now[i]) {
+			return nil, false
+		}
+	}
+	var ret []*reversi.Position
+	for _, m := range now[len(before):] {
+		if !m.IsPass() {
+			ret = append(ret, m.Pos)
+		}
+	}
+	return ret, true
+}
+
+func sameMove(a, b *reversi.Move) bool {
+	if a.Color != b.Color || a.IsPass() != b.IsPass() {
+		return false
+	}
+	return a.IsPass() || *a.Pos == *b.Pos
+}
+
+func (n *mctsNode) descend(moves []*reversi.Position) *mctsNode {
+	for _, pos := range moves {
+		var next *mctsNode
+		for _, c := range n.children {
+			if *c.move == *pos {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		n = next
+	}
+	return n
+}
+
+// playout runs one iteration of selection, expansion, a random playout and
+// backpropagation on the tree rooted at root, whose position is game.
+func (m *MCTS) playout(root *mctsNode, game *reversi.Game, rng *rand.Rand) {
+	g := game.Clone()
+	n := root
+	c := m.Exploration
+	if c == 0 {
+		c = math.Sqrt2
+	}
+	for len(n.untried) == 0 && len(n.children) > 0 {
+		n = n.selectChild(c)
+		g.SetStone(n.color, n.move)
+	}
+	if len(n.untried) > 0 {
+		i := rng.Intn(len(n.untried))
+		pos := n.untried[i]
+		n.untried[i] = n.untried[len(n.untried)-1]
+		n.untried = n.untried[:len(n.untried)-1]
+
+		color := g.GameState.Turn()
+		g.SetStone(color, pos)
+		child := &mctsNode{move: pos, color: color, parent: n}
+		if turn := g.GameState.Turn(); turn.Valid() {
+			child.untried = g.ListAllocatablePositions(turn)
+		}
+		n.children = append(n.children, child)
+		n = child
+	}
+	for g.GameState != reversi.Finish {
+		color := g.GameState.Turn()
+		moves := g.ListAllocatablePositions(color)
+		g.SetStone(color, moves[rng.Intn(len(moves))])
+	}
+
+	winner := g.Winner()
+	for ; n != nil; n = n.parent {
+		n.visits++
+		switch winner {
+		case n.color:
+			n.wins++
+		case reversi.None:
+			n.wins += 0.5
+		}
+	}
+}
+
+// selectChild returns the child with the highest UCT value.
+func (n *mctsNode) selectChild(c float64) *mctsNode {
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	logN := math.Log(float64(n.visits))
+	for _, child := range n.children {
+		v := child.wins/float64(child.visits) + c*math.Sqrt(logN/float64(child.visits))
+		if v > bestValue {
+			best, bestValue = child, v
+		}
+	}
+	return best
+}
+
+// rootStats adds up the statistics of the root moves of trees.
+func rootStats(trees []*mctsNode) []MoveStats {
+	byMove := map[reversi.Position]*MoveStats{}
+	wins := map[reversi.Position]float64{}
+	var ret []*MoveStats
+	for _, t := range trees {
+		for _, c := range t.children {
+			s, ok := byMove[*c.move]
+			if !ok {
+				s = &MoveStats{Move: c.move}
+				byMove[*c.move] = s
+				ret = append(ret, s)
+			}
+			s.Visits += c.visits
+			wins[*c.move] += c.wins
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Visits > ret[j].Visits
+	})
+	stats := make([]MoveStats, len(ret))
+	for i, s := range ret {
+		if s.Visits > 0 {
+			s.WinRate = wins[*s.Move] / float64(s.Visits)
+		}
+		stats[i] = *s
+	}
+	return stats
+}
@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+func visits(stats []MoveStats) int {
+	ret := 0
+	for _, s := range stats {
+		ret += s.Visits
+	}
+	return ret
+}
+
+func TestMCTS_Search(t *testing.T) {
+	anti, _ := reversi.NewGame(reversi.WithSize(6), reversi.WithRules(reversi.AntiReversi{Base: reversi.Octagon{Cut: 1}}))
+	anti.Start()
+	opening, _ := reversi.ParseTranscript("f5d6")
+
+	testcases := []struct {
+		desc string
+		game *reversi.Game
+		mcts *MCTS
+	}{
+		{desc: "when single tree", game: opening, mcts: &MCTS{Playouts: 300, Seed: 1}},
+		{desc: "when parallel", game: opening, mcts: &MCTS{Playouts: 301, Parallel: 4, Seed: 1}},
+		{desc: "when variant rules", game: anti, mcts: &MCTS{Playouts: 200, Seed: 1}},
+	}
+	for _, tc := range testcases {
+		actual, err := tc.mcts.Search(context.Background(), tc.game)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual.Playouts != tc.mcts.Playouts || visits(actual.Stats) != tc.mcts.Playouts {
+			t.Errorf("%s, got: %d playouts %d visits, expected: %d", tc.desc, actual.Playouts, visits(actual.Stats), tc.mcts.Playouts)
+		}
+		legal := tc.game.ListAllocatablePositions(tc.game.GameState.Turn())
+		if len(actual.Stats) != len(legal) {
+			t.Errorf("%s, got: %d moves, expected: %d", tc.desc, len(actual.Stats), len(legal))
+		}
+		for i, s := range actual.Stats {
+			if i > 0 && s.Visits > actual.Stats[i-1].Visits {
+				t.Errorf("%s, stats not sorted by visits", tc.desc)
+			}
+			if s.WinRate < 0 || s.WinRate > 1 {
+				t.Errorf("%s, win rate got: %f", tc.desc, s.WinRate)
+			}
+		}
+		if actual.Move == nil || *actual.Move != *actual.Stats[0].Move {
+			t.Errorf("%s, got move: %v, expected the most visited", tc.desc, actual.Move)
+		}
+		if err := tc.game.Clone().SetStone(tc.game.GameState.Turn(), actual.Move); err != nil {
+			t.Errorf("%s, %v", tc.desc, err)
+		}
+	}
+}
+
+func TestMCTS_Search_fewPlayouts(t *testing.T) {
+	game, _ := reversi.ParseTranscript("f5d6")
+	for _, playouts := range []int{1, 3} {
+		m := &MCTS{Playouts: playouts, Parallel: 4, Seed: 1}
+		// The deadline only guards against a search that never returns.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		actual, err := m.Search(ctx, game)
+		timedOut := ctx.Err() != nil
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if timedOut {
+			t.Fatalf("%d playouts, got: a search stopped by the deadline", playouts)
+		}
+		if actual.Playouts != playouts || visits(actual.Stats) != playouts {
+			t.Errorf("%d playouts, got: %d playouts %d visits, expected: %d", playouts, actual.Playouts, visits(actual.Stats), playouts)
+		}
+	}
+}
+
+func TestMCTS_Search_reuse(t *testing.T) {
+	game, _ := reversi.ParseTranscript("f5")
+	m := &MCTS{Playouts: 500, Reuse: true, Seed: 1}
+	first, err := m.Search(context.Background(), game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.SetStone(reversi.White, first.Move)
+	reply := game.ListAllocatablePositions(reversi.Black)[0]
+	game.SetStone(reversi.Black, reply)
+
+	m.Playouts = 100
+	second, err := m.Search(context.Background(), game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Playouts != 100 || visits(second.Stats) <= 100 {
+		t.Errorf("got: %d playouts %d visits, expected the earlier visits to be kept", second.Playouts, visits(second.Stats))
+	}
+
+	// A position that does not follow the last search starts a new tree.
+	other, _ := reversi.ParseTranscript("f5f6")
+	third, err := m.Search(context.Background(), other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if visits(third.Stats) != 100 {
+		t.Errorf("got: %d visits, expected: 100", visits(third.Stats))
+	}
+}
+
+func TestMCTS_Search_time(t *testing.T) {
+	game, _ := reversi.ParseTranscript("f5")
+	start := time.Now()
+	actual, err := (&MCTS{Time: 30 * time.Millisecond, Parallel: 2}).Search(context.Background(), game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second || actual.Playouts == 0 {
+		t.Errorf("got: %d playouts in %v", actual.Playouts, elapsed)
+	}
+}
+
+func TestMCTS_Search_finished(t *testing.T) {
+	game, _ := reversi.NewGame(reversi.WithLayout([][]int{
+		{1, 1, 1, 1},
+		{1, 1, 1, 1},
+		{1, 1, 1, 1},
+		{1, 1, 1, 0},
+	}))
+	game.Start()
+	if _, err := (&MCTS{}).Search(context.Background(), game); !errors.Is(err, reversi.ErrGameFinished) {
+		t.Errorf("got: %v, expected: %v", err, reversi.ErrGameFinished)
+	}
+}
@@ -0,0 +1,338 @@
+package engine
+
+import (
+	"context"
+	"math/bits"
+	"sort"
+
+	reversi "github.com/myoan/go-reversi"
+	"github.com/myoan/go-reversi/internal/bitboard"
+	"github.com/myoan/go-reversi/internal/zobrist"
+)
+
+// solveSalt keeps the exact results of Solve apart from the heuristic ones of
+// Search in a shared transposition table.
+const solveSalt uint64 = 0x5f0e1d2c3b4a6978
+
+// ttMinEmpties is the number of empties from which the solver uses the
+// transposition table. Closer to the end, looking it up costs more than
+// searching.
+const ttMinEmpties = 7
+
+// SolveMode selects how much Solve proves about a position.
+type SolveMode int
+
+const (
+	// Exact finds the final disc difference under perfect play.
+	Exact SolveMode = iota
+	// WinLossDraw only finds whether the side to move wins, loses or draws,
+	// which searches far fewer positions.
+	WinLossDraw
+)
+
+func (m SolveMode) String() string {
+	if m == WinLossDraw {
+		return "wld"
+	}
+	return "exact"
+}
+
+// Solve plays b out to the end with perfect play and color to move. The score
+// of the result is the final disc difference for color, with the empty
+// squares counted for the winner as in tournament scoring. With WinLossDraw
+// it is only 1, 0 or -1. The PV holds the best move only.
+//
+// Solve returns ctx.Err() when ctx ends before the position is solved.
+func (e *Engine) Solve(ctx context.Context, b *reversi.Board, color reversi.Color, mode SolveMode) (Result, error) {
+	e.start(ctx)
+	defer func() {
+		e.ctx = nil
+	}()
+
+	alpha, beta := -b.Width*b.Height-1, b.Width*b.Height+1
+	if mode == WinLossDraw {
+		alpha, beta = -1, 1
+	}
+	var move *reversi.Position
+	var score int
+	if b.Width == bitboard.Size && b.Height == bitboard.Size {
+		move, score = e.solveBits(b, color, alpha, beta)
+	} else {
+		move, score = e.solveBoard(b, color, alpha, beta)
+	}
+	if e.aborted {
+		return Result{Nodes: e.nodes}, ctx.Err()
+	}
+	if mode == WinLossDraw {
+		score = sign(score)
+	}
+	ret := Result{Move: move, Score: score, Depth: b.Count(reversi.None), Nodes: e.nodes}
+	if move != nil {
+		ret.PV = []*reversi.Position{move}
+	}
+	return ret, nil
+}
+
+// solveBits solves 8x8 boards on bitboards.
+func (e *Engine) solveBits(b *reversi.Board, color reversi.Color, alpha, beta int) (*reversi.Position, int) {
+	var p, o, walls uint64
+	for y := 0; y < bitboard.Size; y++ {
+		for x := 0; x < bitboard.Size; x++ {
+			switch b.Cell(x, y).State {
+			case color:
+				p |= bitboard.Bit(x, y)
+			case color.Opponent():
+				o |= bitboard.Bit(x, y)
+			case reversi.Wall:
+				walls |= bitboard.Bit(x, y)
+			}
+		}
+	}
+	s := &bitSolver{e: e, walls: walls}
+
+	empty := ^(p | o | walls)
+	var buf [bitboard.Size * bitboard.Size]uint64
+	moves := s.order(p, o, empty, bitboard.Moves(p, o, empty), buf[:])
+	if len(moves) == 0 {
+		return nil, s.solve(p, o, alpha, beta, false)
+	}
+	var best uint64
+	for _, m := range moves {
+		f := bitboard.Flips(p, o, m)
+		score := -s.solve(o&^f, p|m|f, -beta, -alpha, false)
+		if e.aborted {
+			return nil, 0
+		}
+		if score > alpha || best == 0 {
+			best = m
+		}
+		if score > alpha {
+			alpha = score
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	i := bits.TrailingZeros64(best)
+	return &reversi.Position{X: i % bitboard.Size, Y: i / bitboard.Size}, alpha
+}
+
+type bitSolver struct {
+	e     *Engine
+	walls uint64
+}
+
+// Quadrants of the board used for parity ordering.
+var quadrants = []uint64{
+	0x000000000f0f0f0f,
+	0x00000000f0f0f0f0,
+	0x0f0f0f0f00000000,
+	0xf0f0f0f000000000,
+}
+
+// fastestFirst is the number of empties above which moves are ordered by the
+// mobility they leave the opponent rather than by parity alone.
+const fastestFirst = 7
+
+func (s *bitSolver) solve(p, o uint64, alpha, beta int, passed bool) int {
+	e := s.e
+	e.nodes++
+	if e.stopped() {
+		return 0
+	}
+	empty := ^(p | o | s.walls)
+	moves := bitboard.Moves(p, o, empty)
+	if moves == 0 {
+		if passed {
+			return finalDiff(bits.OnesCount64(p), bits.OnesCount64(o), bits.OnesCount64(empty))
+		}
+		return -s.solve(o, p, -beta, -alpha, true)
+	}
+
+	empties := bits.OnesCount64(empty)
+	useTT := e.TT != nil && empties >= ttMinEmpties
+	var key uint64
+	ttMove := NoMove
+	if useTT {
+		key = bitsHash(p, o, s.walls)
+		if entry, ok := e.TT.Probe(key); ok {
+			if score, ok := entry.cutoff(empties, &alpha, &beta); ok {
+				return score
+			}
+			ttMove = int(entry.Move)
+		}
+	}
+
+	alpha0 := alpha
+	best := -64 - 1
+	var bestMove uint64
+	var buf [bitboard.Size * bitboard.Size]uint64
+	ordered := s.order(p, o, empty, moves, buf[:])
+	if ttMove != NoMove {
+		for i, m := range ordered {
+			if m == 1<<uint(ttMove) {
+				copy(ordered[1:i+1], ordered[:i])
+				ordered[0] = m
+				break
+			}
+		}
+	}
+	for _, m := range ordered {
+		f := bitboard.Flips(p, o, m)
+		score := -s.solve(o&^f, p|m|f, -beta, -alpha, false)
+		if e.aborted {
+			return 0
+		}
+		if score > best {
+			best, bestMove = score, m
+		}
+		if score > alpha {
+			alpha = score
+			if alpha >= beta {
+				break
+			}
+		}
+	}
+	if useTT {
+		e.TT.Store(key, Entry{
+			Score: int32(best),
+			Depth: int16(empties),
+			Move:  int16(bits.TrailingZeros64(bestMove)),
+			Bound: bound(best, alpha0, beta),
+		})
+	}
+	return best
+}
+
+// bitsHash hashes a position of the solver with p to move. It takes the
+// cell keys of reversi.Board.Hash, but by side to move rather than by color,
+// so it does not match Board.Hash. Walls are hashed too, so that games on
+// different blocked squares can share a table.
+func bitsHash(p, o, walls uint64) uint64 {
+	ret := solveSalt
+	for ; p != 0; p &= p - 1 {
+		ret ^= zobrist.Key(bits.TrailingZeros64(p), 1)
+	}
+	for ; o != 0; o &= o - 1 {
+		ret ^= zobrist.Key(bits.TrailingZeros64(o), 2)
+	}
+	for ; walls != 0; walls &= walls - 1 {
+		ret ^= zobrist.Key(bits.TrailingZeros64(walls), 3)
+	}
+	return ret
+}
+
+// order lists the moves in moves, one bit each, into buf. Moves in quadrants
+// with an odd number of empties come first; with many empties, moves leaving
+// the opponent fewer replies come before that.
+func (s *bitSolver) order(p, o, empty, moves uint64, buf []uint64) []uint64 {
+	var odd uint64
+	for _, q := range quadrants {
+		if bits.OnesCount64(empty&q)%2 == 1 {
+			odd |= q
+		}
+	}
+	ret := buf[:0]
+	if bits.OnesCount64(empty) <= fastestFirst {
+		for _, set := range []uint64{moves & odd, moves &^ odd} {
+			for ; set != 0; set &= set - 1 {
+				ret = append(ret, set&-set)
+			}
+		}
+		return ret
+	}
+
+	var keys [bitboard.Size * bitboard.Size]int
+	for m := moves; m != 0; m &= m - 1 {
+		move := m & -m
+		f := bitboard.Flips(p, o, move)
+		key := 2 * bits.OnesCount64(bitboard.Moves(o&^f, p|move|f, empty&^move))
+		if move&odd == 0 {
+			key++
+		}
+		// Insertion sort: there are rarely more than a dozen moves.
+		n := len(ret)
+		ret = append(ret, move)
+		for n > 0 && keys[n-1] > key {
+			ret[n], keys[n] = ret[n-1], keys[n-1]
+			n--
+		}
+		ret[n], keys[n] = move, key
+	}
+	return ret
+}
+
+// solveBoard solves boards of other sizes through the Board API, ordering
+// moves fastest first.
+func (e *Engine) solveBoard(b *reversi.Board, color reversi.Color, alpha, beta int) (*reversi.Position, int) {
+	e.nodes++
+	if e.stopped() {
+		return nil, 0
+	}
+	opponent := color.Opponent()
+	moves := b.ListAllocatablePositions(color)
+	if len(moves) == 0 {
+		if b.Mobility(opponent) == 0 {
+			return nil, finalDiff(b.Count(color), b.Count(opponent), b.Count(reversi.None))
+		}
+		_, score := e.solveBoard(b, opponent, -beta, -alpha)
+		return nil, -score
+	}
+
+	children := make([]child, 0, len(moves))
+	for _, m := range moves {
+		next, err := b.Apply(color, m)
+		if err != nil {
+			continue
+		}
+		children = append(children, child{move: m, board: next, key: next.Mobility(opponent)})
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].key < children[j].key
+	})
+
+	var best *reversi.Position
+	bestScore := -b.Width*b.Height - 1
+	for _, c := range children {
+		_, score := e.solveBoard(c.board, opponent, -beta, -alpha)
+		score = -score
+		if e.aborted {
+			return nil, 0
+		}
+		if score > bestScore {
+			best, bestScore = c.move, score
+		}
+		if score > alpha {
+			alpha = score
+			if alpha >= beta {
+				break
+			}
+		}
+	}
+	return best, bestScore
+}
+
+// finalDiff is the disc difference of a finished game, with the empty
+// squares going to the winner.
+func finalDiff(p, o, empties int) int {
+	diff := p - o
+	switch {
+	case diff > 0:
+		return diff + empties
+	case diff < 0:
+		return diff - empties
+	default:
+		return 0
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+// bruteForce solves b without pruning or ordering.
+func bruteForce(b *reversi.Board, color reversi.Color) int {
+	opponent := color.Opponent()
+	moves := b.ListAllocatablePositions(color)
+	if len(moves) == 0 {
+		if b.Mobility(opponent) == 0 {
+			return finalDiff(b.Count(color), b.Count(opponent), b.Count(reversi.None))
+		}
+		return -bruteForce(b, opponent)
+	}
+	best := -b.Width*b.Height - 1
+	for _, m := range moves {
+		next, _ := b.Apply(color, m)
+		if score := -bruteForce(next, opponent); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// randomPosition plays random moves on a size x size board until empties
+// squares are left, and returns the position with the side to move.
+func randomPosition(t testing.TB, rng *rand.Rand, size, empties int) (*reversi.Board, reversi.Color) {
+	t.Helper()
+	for {
+		game, err := reversi.NewGame(reversi.WithSize(size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.Start()
+		b := game.Board()
+		for game.GameState != reversi.Finish && b.Count(reversi.None) > empties {
+			color := game.GameState.Turn()
+			moves := game.ListAllocatablePositions(color)
+			game.SetStone(color, moves[rng.Intn(len(moves))])
+			b = game.Board()
+		}
+		if game.GameState != reversi.Finish {
+			return b, game.GameState.Turn()
+		}
+	}
+}
+
+func TestEngine_Solve(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	testcases := []struct {
+		desc    string
+		size    int
+		empties int
+		count   int
+	}{
+		{desc: "when 8x8", size: 8, empties: 9, count: 20},
+		{desc: "when 6x6", size: 6, empties: 8, count: 10},
+	}
+	for _, tc := range testcases {
+		for i := 0; i < tc.count; i++ {
+			b, color := randomPosition(t, rng, tc.size, tc.empties)
+			expected := bruteForce(b, color)
+
+			exact, err := New(nil).Solve(context.Background(), b, color, Exact)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exact.Score != expected {
+				t.Errorf("%s #%d, got: %d, expected: %d", tc.desc, i, exact.Score, expected)
+			}
+			if exact.Move != nil {
+				next, err := b.Apply(color, exact.Move)
+				if err != nil {
+					t.Fatalf("%s #%d, %v", tc.desc, i, err)
+				}
+				if score := -bruteForce(next, color.Opponent()); score != expected {
+					t.Errorf("%s #%d, move %s got: %d, expected: %d", tc.desc, i, exact.Move, score, expected)
+				}
+			}
+
+			wld, err := New(nil).Solve(context.Background(), b, color, WinLossDraw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if wld.Score != sign(expected) {
+				t.Errorf("%s #%d wld, got: %d, expected: %d", tc.desc, i, wld.Score, sign(expected))
+			}
+		}
+	}
+}
+
+func TestEngine_Solve_canceled(t *testing.T) {
+	b, color := randomPosition(t, rand.New(rand.NewSource(2)), 8, 30)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := New(nil).Solve(ctx, b, color, Exact); err != context.Canceled {
+		t.Errorf("got: %v, expected: %v", err, context.Canceled)
+	}
+}
+
+var ffoLong = flag.Bool("ffo.long", false, "also solve the FFO positions with more than 20 empties, which takes minutes")
+
+// TestEngine_Solve_ffo checks the solver against positions of the FFO endgame
+// test suite, held in testdata/ffo.obf as OBF lines followed by the published
+// exact score and best move, e.g. "<64 cells> X; +18 g8".
+func TestEngine_Solve_ffo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping FFO positions in short mode")
+	}
+	f, err := os.Open("testdata/ffo.obf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+		position, result, _ := strings.Cut(line, ";")
+		fields := strings.Fields(result)
+		if len(fields) != 2 {
+			t.Fatalf("line %d: expected a score and a move", n)
+		}
+		expected, err := strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+		if err != nil {
+			t.Fatalf("line %d: %v", n, err)
+		}
+		var game reversi.Game
+		if err := game.UnmarshalText([]byte(position)); err != nil {
+			t.Fatalf("line %d: %v", n, err)
+		}
+		if !*ffoLong && game.Board().Count(reversi.None) > 20 {
+			t.Logf("line %d: skipped without -ffo.long", n)
+			continue
+		}
+		e := New(nil)
+		e.TT = NewTranspositionTable(1 << 20)
+		actual, err := e.Solve(context.Background(), game.Board(), game.GameState.Turn(), Exact)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual.Score != expected {
+			t.Errorf("line %d, got: %d, expected: %d", n, actual.Score, expected)
+		}
+		if actual.Move == nil || actual.Move.String() != fields[1] {
+			t.Errorf("line %d, got: %v, expected: %s", n, actual.Move, fields[1])
+		}
+	}
+}
+
+func BenchmarkEngine_Solve(b *testing.B) {
+	board, color := randomPosition(b, rand.New(rand.NewSource(3)), 8, 18)
+	for i := 0; i < b.N; i++ {
+		New(nil).Solve(context.Background(), board, color, Exact)
+	}
+}
+
+func BenchmarkEngine_Solve_tt(b *testing.B) {
+	board, color := randomPosition(b, rand.New(rand.NewSource(3)), 8, 18)
+	for i := 0; i < b.N; i++ {
+		e := New(nil)
+		e.TT = NewTranspositionTable(1 << 20)
+		e.Solve(context.Background(), board, color, Exact)
+	}
+}
@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+// Limits bounds the search done by Think.
+type Limits struct {
+	// Depth is the deepest iteration. 0 searches until every empty square is
+	// covered.
+	Depth int
+	// Time is the budget for the whole search. 0 means no budget.
+	Time time.Duration
+}
+
+// Progress reports a completed iteration of Think.
+type Progress struct {
+	Depth   int
+	Score   int
+	Nodes   int64 // nodes searched since Think started
+	Elapsed time.Duration
+	NPS     int64 // nodes per second
+	PV      []*reversi.Position
+}
+
+// Think searches b with iterative deepening until the limits are reached or
+// ctx ends, and returns the result of the deepest completed iteration. When
+// not even the first iteration completes, the move that orders first is
+// returned with Depth 0, so there is always a move to play when one exists.
+func (e *Engine) Think(ctx context.Context, b *reversi.Board, color reversi.Color, limits Limits) Result {
+	if limits.Time > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Time)
+		defer cancel()
+	}
+	maxDepth := b.Count(reversi.None)
+	if limits.Depth > 0 && limits.Depth < maxDepth {
+		maxDepth = limits.Depth
+	}
+
+	start := time.Now()
+	e.start(nil)
+	best := e.root(b, color, 0, nil)
+	if children := e.children(b, color); len(children) > 0 {
+		best.Move = children[0].move
+		best.PV = []*reversi.Position{best.Move}
+	}
+
+	e.ctx = ctx
+	defer func() {
+		e.ctx = nil
+	}()
+	for depth := 1; depth <= maxDepth && ctx.Err() == nil; depth++ {
+		r := e.root(b, color, depth, best.Move)
+		if e.aborted {
+			break
+		}
+		best = r
+		if e.Progress != nil {
+			elapsed := time.Since(start)
+			e.Progress(Progress{
+				Depth:   depth,
+				Score:   r.Score,
+				Nodes:   r.Nodes,
+				Elapsed: elapsed,
+				NPS:     nps(r.Nodes, elapsed),
+				PV:      r.PV,
+			})
+		}
+		if r.Score >= WinScore || r.Score <= -WinScore {
+			break
+		}
+	}
+	best.Nodes = e.nodes
+	return best
+}
+
+func nps(nodes int64, elapsed time.Duration) int64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return int64(float64(nodes) / elapsed.Seconds())
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+func TestEngine_Think(t *testing.T) {
+	b, color := position(t, "f5d6c3d3c4f4c5b3c2e6c6b4")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testcases := []struct {
+		desc   string
+		ctx    context.Context
+		limits Limits
+		depth  int
+	}{
+		{desc: "when depth is limited", ctx: context.Background(), limits: Limits{Depth: 3}, depth: 3},
+		{desc: "when context is canceled", ctx: canceled, limits: Limits{Depth: 3}, depth: 0},
+	}
+	for _, tc := range testcases {
+		e := New(nil)
+		var depths []int
+		e.Progress = func(p Progress) {
+			depths = append(depths, p.Depth)
+		}
+		actual := e.Think(tc.ctx, b, color, tc.limits)
+		if actual.Depth != tc.depth || actual.Move == nil {
+			t.Errorf("%s, got: depth %d move %v, expected: depth %d", tc.desc, actual.Depth, actual.Move, tc.depth)
+		}
+		if len(depths) != tc.depth {
+			t.Errorf("%s, got progress: %v, expected: %d reports", tc.desc, depths, tc.depth)
+		}
+		if tc.depth > 0 {
+			expected := New(nil).Search(b, color, tc.depth)
+			if actual.Score != expected.Score {
+				t.Errorf("%s, got: %d, expected: %d", tc.desc, actual.Score, expected.Score)
+			}
+		}
+	}
+}
+
+func TestEngine_Think_time(t *testing.T) {
+	b, color := position(t, "f5")
+	start := time.Now()
+	actual := New(nil).Think(context.Background(), b, color, Limits{Time: 50 * time.Millisecond})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("got: %v, expected to stop after about 50ms", elapsed)
+	}
+	if actual.Move == nil || actual.Depth == 0 {
+		t.Errorf("got: depth %d move %v", actual.Depth, actual.Move)
+	}
+}
+
+func TestEngine_Think_endgame(t *testing.T) {
+	b := reversi.NewBoard([][]int{
+		{0, 2, 1, 1},
+		{1, 1, 1, 1},
+		{1, 2, 2, 1},
+		{1, 1, 1, 0},
+	})
+	actual := New(nil).Think(context.Background(), b, reversi.Black, Limits{})
+	if actual.Depth > 2 || actual.Score < WinScore {
+		t.Errorf("got: depth %d score %d", actual.Depth, actual.Score)
+	}
+}
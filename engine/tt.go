@@ -0,0 +1,126 @@
+package engine
+
+// Bound tells how a stored score relates to the true value of a position.
+type Bound uint8
+
+const (
+	BoundNone  Bound = iota
+	BoundExact       // the score is the value
+	BoundLower       // the value is at least the score
+	BoundUpper       // the value is at most the score
+)
+
+// Entry is a search result stored in a TranspositionTable.
+type Entry struct {
+	Score int32
+	Depth int16 // plies searched below the position
+	Move  int16 // best move as y*width+x, or -1
+	Bound Bound
+}
+
+// NoMove is the Move of an entry without a best move.
+const NoMove = -1
+
+type slot struct {
+	key   uint64
+	entry Entry
+	age   uint8
+}
+
+// TranspositionTable is a fixed-size hash table of search results, keyed by
+// position hash. Each key maps to a bucket of two slots: one keeps the
+// deepest result of the current search, the other always takes the latest
+// result. It can be shared by successive searches, including Search, Think
+// and Solve, but not by concurrent ones.
+type TranspositionTable struct {
+	slots []slot
+	mask  uint64
+	age   uint8
+}
+
+// NewTranspositionTable returns a table holding up to size entries, rounded
+// down to a power of two and at least 2.
+func NewTranspositionTable(size int) *TranspositionTable {
+	n := 2
+	for n*2 <= size {
+		n *= 2
+	}
+	return &TranspositionTable{slots: make([]slot, n), mask: uint64(n/2 - 1)}
+}
+
+// Size returns the number of entries the table can hold.
+func (t *TranspositionTable) Size() int {
+	return len(t.slots)
+}
+
+// NewSearch marks the entries stored so far as old, so that they give way to
+// the results of the next search.
+func (t *TranspositionTable) NewSearch() {
+	t.age++
+}
+
+// Clear removes every entry.
+func (t *TranspositionTable) Clear() {
+	for i := range t.slots {
+		t.slots[i] = slot{}
+	}
+	t.age = 0
+}
+
+// Probe returns the entry stored for key.
+func (t *TranspositionTable) Probe(key uint64) (Entry, bool) {
+	i := (key & t.mask) * 2
+	for _, s := range t.slots[i : i+2] {
+		if s.key == key && s.entry.Bound != BoundNone {
+			return s.entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Store saves e for key. The deep slot of the bucket is replaced when it
+// holds the same key, an entry from an earlier search, or a shallower one;
+// otherwise e goes to the second slot.
+func (t *TranspositionTable) Store(key uint64, e Entry) {
+	i := (key & t.mask) * 2
+	deep := &t.slots[i]
+	if deep.entry.Bound == BoundNone || deep.key == key || deep.age != t.age || e.Depth >= deep.entry.Depth {
+		*deep = slot{key: key, entry: e, age: t.age}
+		return
+	}
+	t.slots[i+1] = slot{key: key, entry: e, age: t.age}
+}
+
+// cutoff narrows alpha and beta with an entry searched at least depth deep,
+// and reports whether the entry settles the position.
+func (e Entry) cutoff(depth int, alpha, beta *int) (int, bool) {
+	if int(e.Depth) < depth {
+		return 0, false
+	}
+	score := int(e.Score)
+	switch e.Bound {
+	case BoundExact:
+		return score, true
+	case BoundLower:
+		if score > *alpha {
+			*alpha = score
+		}
+	case BoundUpper:
+		if score < *beta {
+			*beta = score
+		}
+	}
+	return score, *alpha >= *beta
+}
+
+// bound classifies the result best of a search in the window (alpha, beta).
+func bound(best, alpha, beta int) Bound {
+	switch {
+	case best <= alpha:
+		return BoundUpper
+	case best >= beta:
+		return BoundLower
+	default:
+		return BoundExact
+	}
+}
@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	reversi "github.com/myoan/go-reversi"
+)
+
+func TestTranspositionTable(t *testing.T) {
+	tt := NewTranspositionTable(10)
+	if tt.Size() != 8 {
+		t.Fatalf("size got: %d, expected: 8", tt.Size())
+	}
+	// Keys 1, 5 and 9 share a bucket in a table of four buckets.
+	testcases := []struct {
+		desc     string
+		key      uint64
+		entry    Entry
+		probe    uint64
+		expected int32
+		found    bool
+	}{
+		{desc: "when empty", probe: 1},
+		{desc: "when stored", key: 1, entry: Entry{Score: 10, Depth: 5, Bound: BoundExact}, probe: 1, expected: 10, found: true},
+		{desc: "when shallower goes to the second slot", key: 5, entry: Entry{Score: 20, Depth: 2, Bound: BoundLower}, probe: 1, expected: 10, found: true},
+		{desc: "when second slot is probed", probe: 5, expected: 20, found: true},
+		{desc: "when second slot is replaced", key: 9, entry: Entry{Score: 30, Depth: 1, Bound: BoundUpper}, probe: 5},
+		{desc: "when deeper replaces the first slot", key: 5, entry: Entry{Score: 40, Depth: 6, Bound: BoundExact}, probe: 1},
+		{desc: "when other key", probe: 2},
+	}
+	for _, tc := range testcases {
+		if tc.entry.Bound != BoundNone {
+			tt.Store(tc.key, tc.entry)
+		}
+		actual, ok := tt.Probe(tc.probe)
+		if ok != tc.found || actual.Score != tc.expected {
+			t.Errorf("%s, got: %v %v, expected: %d %v", tc.desc, actual.Score, ok, tc.expected, tc.found)
+		}
+	}
+
+	// Entries of an earlier search give way even to shallower ones.
+	tt.NewSearch()
+	tt.Store(1, Entry{Score: 50, Depth: 1, Bound: BoundExact})
+	if actual, ok := tt.Probe(1); !ok || actual.Score != 50 {
+		t.Errorf("after new search, got: %v %v, expected: 50", actual.Score, ok)
+	}
+	tt.Clear()
+	if _, ok := tt.Probe(1); ok {
+		t.Errorf("after clear, entry found")
+	}
+}
+
+func TestEngine_Solve_tt(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	shared := New(nil)
+	shared.TT = NewTranspositionTable(1 << 12)
+	for i := 0; i < 10; i++ {
+		b, color := randomPosition(t, rng, 8, 12)
+		expected, err := New(nil).Solve(context.Background(), b, color, Exact)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, mode := range []SolveMode{WinLossDraw, Exact} {
+			actual, err := shared.Solve(context.Background(), b, color, mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			score := expected.Score
+			if mode == WinLossDraw {
+				score = sign(score)
+			}
+			if actual.Score != score {
+				t.Errorf("#%d %s, got: %d, expected: %d", i, mode, actual.Score, score)
+			}
+		}
+	}
+}
+
+func TestEngine_Solve_tt_walls(t *testing.T) {
+	if bitsHash(1, 2, 4) == bitsHash(1, 2, 8) {
+		t.Fatalf("got: the same key for different walls")
+	}
+	b, color := randomPosition(t, rand.New(rand.NewSource(5)), 8, 14)
+	var empties [][2]int
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if b.Cell(x, y).State == reversi.None {
+				empties = append(empties, [2]int{x, y})
+			}
+		}
+	}
+	shared := New(nil)
+	shared.TT = NewTranspositionTable(1 << 16)
+	// Walling off different squares leaves the same discs.
+	for i, wall := range empties[:4] {
+		layout := make([][]int, b.Height)
+		for y, line := range b.Snapshot() {
+			layout[y] = make([]int, len(line))
+			for x, c := range line {
+				layout[y][x] = int(c.State)
+			}
+		}
+		layout[wall[1]][wall[0]] = int(reversi.Wall)
+		walled := reversi.NewBoard(layout)
+		expected, err := New(nil).Solve(context.Background(), walled, color, Exact)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := shared.Solve(context.Background(), walled, color, Exact)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual.Score != expected.Score {
+			t.Errorf("#%d, got: %d, expected: %d", i, actual.Score, expected.Score)
+		}
+	}
+}
+
+func TestEngine_Search_tt(t *testing.T) {
+	b, color := position(t, "f5d6c3d3c4f4c5b3c2e6c6b4")
+	e := New(nil)
+	e.TT = NewTranspositionTable(1 << 16)
+	first := e.Search(b, color, 5)
+	second := e.Search(b, color, 5)
+	if second.Nodes >= first.Nodes || second.Score != first.Score {
+		t.Errorf("got: %d then %d nodes, scores %d and %d", first.Nodes, second.Nodes, first.Score, second.Score)
+	}
+	if _, err := b.Apply(color, second.Move); err != nil {
+		t.Errorf("%v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package reversi
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidColor = errors.New("invalid color")
+	ErrOutOfBounds  = errors.New("out of bounds")
+	ErrOccupied     = errors.New("cell is occupied")
+	ErrNoFlips      = errors.New("no discs to flip")
+	ErrOutOfTurn    = errors.New("out of turn")
+	ErrGameFinished = errors.New("game is finished")
+	ErrInvalidSize  = errors.New("invalid board size")
+	ErrLayout       = errors.New("invalid layout")
+	ErrNotation     = errors.New("invalid notation")
+	ErrGGF          = errors.New("invalid GGF")
+	ErrWthor        = errors.New("invalid WTHOR data")
+	ErrJSON         = errors.New("invalid game JSON")
+)
+
+// InvalidColorError is returned when a value that is not a player color is
+// passed where Black or White is expected. It matches ErrInvalidColor.
+type InvalidColorError struct {
+	Color Color
+}
+
+func (e *InvalidColorError) Error() string {
+	return fmt.Sprintf("Invalid color %d", int(e.Color))
+}
+
+func (e *InvalidColorError) Unwrap() error {
+	return ErrInvalidColor
+}
+
+// IllegalMoveError describes a rejected move. Reason is one of the sentinel
+// errors above, so callers can test it with errors.Is.
+type IllegalMoveError struct {
+	Pos    Position
+	Color  Color
+	Reason error
+}
+
+func (e *IllegalMoveError) Error() string {
+	return fmt.Sprintf("Illegal move (%d, %d) by %s: %v", e.Pos.X, e.Pos.Y, e.Color, e.Reason)
+}
+
+func (e *IllegalMoveError) Unwrap() error {
+	return e.Reason
+}
+
+// LayoutError reports a malformed starting layout. Row and Col locate the
+// offending cell; Col is -1 when the whole row is at fault. It matches
+// ErrLayout.
+type LayoutError struct {
+	Row    int
+	Col    int
+	Reason string
+}
+
+func (e *LayoutError) Error() string {
+	switch {
+	case e.Col >= 0:
+		return fmt.Sprintf("Invalid layout at (%d, %d): %s", e.Col, e.Row, e.Reason)
+	case e.Row >= 0:
+		return fmt.Sprintf("Invalid layout at row %d: %s", e.Row, e.Reason)
+	default:
+		return fmt.Sprintf("Invalid layout: %s", e.Reason)
+	}
+}
+
+func (e *LayoutError) Unwrap() error {
+	return ErrLayout
+}
+
+func validateColor(color Color) error {
+	if !color.Valid() {
+		return &InvalidColorError{Color: color}
+	}
+	return nil
+}
+
+func illegalMove(color Color, pos *Position, reason error) error {
+	return &IllegalMoveError{Pos: *pos, Color: color, Reason: reason}
+}
@@ -2,45 +2,148 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"flag"
 	"fmt"
+	"math/rand"
 	"os"
-	"strconv"
+	"strings"
+	"time"
 
 	reversi "github.com/myoan/go-reversi"
+	"github.com/myoan/go-reversi/book"
+	"github.com/myoan/go-reversi/engine"
 )
 
-func readPosition() *reversi.Position {
-	stdin := bufio.NewScanner(os.Stdin)
-	fmt.Printf("X: ")
-	stdin.Scan()
-	xStr := stdin.Text()
-	fmt.Printf("Y: ")
-	stdin.Scan()
-	yStr := stdin.Text()
-	x, _ := strconv.Atoi(xStr)
-	y, _ := strconv.Atoi(yStr)
-	return &reversi.Position{X: x, Y: y}
+func readPosition(stdin *bufio.Scanner) *reversi.Position {
+	for {
+		fmt.Printf("Move (e.g. f5): ")
+		if !stdin.Scan() {
+			os.Exit(0)
+		}
+		pos, err := reversi.ParsePosition(strings.TrimSpace(stdin.Text()))
+		if err == nil {
+			return pos
+		}
+		fmt.Println(err)
+	}
+}
+
+// solveEmpties is the number of empty squares from which the engine plays
+// perfectly instead of searching.
+const solveEmpties = 14
+
+func thinkPosition(eng *engine.Engine, bk *book.Book, game *reversi.Game, color reversi.Color, budget time.Duration) *reversi.Position {
+	b := game.Board()
+	if bk != nil {
+		if pos := book.Sample(bk.Lookup(b, color), rand.New(rand.NewSource(time.Now().UnixNano()))); pos != nil {
+			fmt.Printf("Engine plays %s from the book\n", pos)
+			return pos
+		}
+	}
+	if b.Count(reversi.None) <= solveEmpties {
+		ctx, cancel := context.WithTimeout(context.Background(), budget)
+		defer cancel()
+		if r, err := eng.Solve(ctx, b, color, engine.Exact); err == nil {
+			fmt.Printf("solved: %+d\n", r.Score)
+			fmt.Printf("Engine plays %s\n", r.Move)
+			return r.Move
+		}
+	}
+	r := eng.Think(context.Background(), b, color, engine.Limits{Time: budget})
+	fmt.Printf("Engine plays %s\n", r.Move)
+	return r.Move
+}
+
+func printProgress(p engine.Progress) {
+	pv := make([]string, len(p.PV))
+	for i, m := range p.PV {
+		if m == nil {
+			pv[i] = "pass"
+		} else {
+			pv[i] = m.String()
+		}
+	}
+	fmt.Printf("depth %d score %d nodes %d nps %d pv %s\n", p.Depth, p.Score, p.Nodes, p.NPS, strings.Join(pv, " "))
 }
 
 func main() {
-	game := reversi.NewGame()
+	size := flag.Int("size", 8, "board size (even, at least 4)")
+	styleName := flag.String("style", "ascii", "board style: ascii, unicode or ansi")
+	engineName := flag.String("engine", "none", "color played by the engine: black, white or none")
+	think := flag.Duration("think", 2*time.Second, "time the engine may think per move")
+	bookPath := flag.String("book", "", "opening book file for the engine")
+	flag.Parse()
+
+	style, err := reversi.ParseStyle(*styleName)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	game, err := reversi.NewGame(reversi.WithSize(*size))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	var engineColor reversi.Color
+	switch *engineName {
+	case "black":
+		engineColor = reversi.Black
+	case "white":
+		engineColor = reversi.White
+	case "none":
+	default:
+		fmt.Printf("unknown engine color %q\n", *engineName)
+		os.Exit(1)
+	}
+	eng := engine.New(nil)
+	eng.Progress = printProgress
+	eng.TT = engine.NewTranspositionTable(1 << 20)
+	var bk *book.Book
+	if *bookPath != "" {
+		f, err := os.Open(*bookPath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		bk, err = book.Read(f)
+		f.Close()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	stdin := bufio.NewScanner(os.Stdin)
 	for {
 		switch game.GameState {
 		case reversi.Prepare:
-			game.GameState = reversi.BlackTurn
-		case reversi.BlackTurn:
-			fmt.Println("Black turn")
-			pos := readPosition()
-			game.SetStone(1, pos)
-		case reversi.WhiteTurn:
-			fmt.Println("White turn")
-			pos := readPosition()
-			game.SetStone(2, pos)
+			game.Start()
+		case reversi.BlackTurn, reversi.WhiteTurn:
+			color := game.GameState.Turn()
+			name := color.String()
+			fmt.Printf("%s%s turn\n", strings.ToUpper(name[:1]), name[1:])
+			var pos *reversi.Position
+			if color == engineColor {
+				pos = thinkPosition(eng, bk, game, color, *think)
+			} else {
+				pos = readPosition(stdin)
+			}
+			if err := game.SetStone(color, pos); err != nil {
+				fmt.Println(err)
+			}
 		case reversi.Finish:
-			fmt.Println("Finish")
-			fmt.Printf("%d win!\n", game.Winner())
+			fmt.Printf("Finish (%s)\n", game.EndReason())
+			fmt.Println(game.Transcript())
+			if winner := game.Winner(); winner == reversi.None {
+				fmt.Println("Draw")
+			} else {
+				fmt.Printf("%s win!\n", winner)
+			}
 			return
 		}
-		game.Show()
+		game.Render(os.Stdout, reversi.WithStyle(style))
+		fmt.Println("")
 	}
 }
@@ -0,0 +1,78 @@
+package reversi
+
+import (
+	"image"
+	"image/color"
+)
+
+// bitmapFont is a 5x7 font with the digits and lower case letters, enough
+// for coordinates and move numbers in PNG images.
+var bitmapFont = map[rune][7]string{
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'a': {".....", ".....", ".###.", "....#", ".####", "#...#", ".####"},
+	'b': {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "####."},
+	'c': {".....", ".....", ".###.", "#....", "#....", "#...#", ".###."},
+	'd': {"....#", "....#", ".##.#", "#..##", "#...#", "#...#", ".####"},
+	'e': {".....", ".....", ".###.", "#...#", "#####", "#....", ".###."},
+	'f': {"..##.", ".#..#", ".#...", "###..", ".#...", ".#...", ".#..."},
+	'g': {".....", ".####", "#...#", "#...#", ".####", "....#", ".###."},
+	'h': {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "#...#"},
+	'i': {"..#..", ".....", ".##..", "..#..", "..#..", "..#..", ".###."},
+	'j': {"...#.", ".....", "..##.", "...#.", "...#.", "#..#.", ".##.."},
+	'k': {"#....", "#....", "#..#.", "#.#..", "##...", "#.#..", "#..#."},
+	'l': {".##..", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'm': {".....", ".....", "##.#.", "#.#.#", "#.#.#", "#...#", "#...#"},
+	'n': {".....", ".....", "#.##.", "##..#", "#...#", "#...#", "#...#"},
+	'o': {".....", ".....", ".###.", "#...#", "#...#", "#...#", ".###."},
+	'p': {".....", "####.", "#...#", "#...#", "####.", "#....", "#...."},
+	'q': {".....", ".####", "#...#", "#...#", ".####", "....#", "....#"},
+	'r': {".....", ".....", "#.##.", "##..#", "#....", "#....", "#...."},
+	's': {".....", ".....", ".####", "#....", ".###.", "....#", "####."},
+	't': {".#...", ".#...", "###..", ".#...", ".#...", ".#..#", "..##."},
+	'u': {".....", ".....", "#...#", "#...#", "#...#", "#..##", ".##.#"},
+	'v': {".....", ".....", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'w': {".....", ".....", "#...#", "#...#", "#.#.#", "#.#.#", ".#.#."},
+	'x': {".....", ".....", "#...#", ".#.#.", "..#..", ".#.#.", "#...#"},
+	'y': {".....", "#...#", "#...#", ".####", "....#", "#...#", ".###."},
+	'z': {".....", ".....", "#####", "...#.", "..#..", ".#...", "#####"},
+}
+
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+)
+
+// drawText draws s centred on (cx, cy), each font pixel scale pixels wide.
+// Characters missing from the font are left blank.
+func drawText(img *image.RGBA, s string, cx, cy, scale int, c color.Color) {
+	runes := []rune(s)
+	width := (len(runes)*(glyphWidth+1) - 1) * scale
+	x0 := cx - width/2
+	y0 := cy - glyphHeight*scale/2
+	for i, r := range runes {
+		glyph, ok := bitmapFont[r]
+		if !ok {
+			continue
+		}
+		left := x0 + i*(glyphWidth+1)*scale
+		for row, line := range glyph {
+			for col, dot := range line {
+				if dot != '#' {
+					continue
+				}
+				x := left + col*scale
+				y := y0 + row*scale
+				fillRect(img, image.Rect(x, y, x+scale, y+scale), c)
+			}
+		}
+	}
+}
@@ -1,18 +1,25 @@
 package reversi
 
 import (
-	"errors"
 	"fmt"
+	"log/slog"
+	"os"
 )
 
 type Game struct {
 	GameState GameState
 	board     *Board
+	history   []*Move
+	ply       int
+	endReason EndReason
+	first     Color
+	rules     Rules
+	logger    *slog.Logger
 }
 
 type Position struct {
-	X int
-	Y int
+	X int `json:"x"`
+	Y int `json:"y"`
 }
 
 type GameState int
@@ -24,24 +31,106 @@ const (
 	Finish
 )
 
+func (s GameState) String() string {
+	switch s {
+	case Prepare:
+		return "prepare"
+	case BlackTurn:
+		return "black turn"
+	case WhiteTurn:
+		return "white turn"
+	case Finish:
+		return "finish"
+	default:
+		return fmt.Sprintf("GameState(%d)", int(s))
+	}
+}
+
+// Turn returns the color to move in state s, or None outside of a turn.
+func (s GameState) Turn() Color {
+	switch s {
+	case BlackTurn:
+		return Black
+	case WhiteTurn:
+		return White
+	default:
+		return None
+	}
+}
+
+func turnOf(color Color) GameState {
+	if color == Black {
+		return BlackTurn
+	}
+	return WhiteTurn
+}
+
+type EndReason int
+
+const (
+	NotFinished EndReason = iota
+	BoardFull             // no empty cell is left
+	BothBlocked           // empty cells remain but neither side can move
+	Wipeout               // one color has no disc left
+)
+
+func (r EndReason) String() string {
+	switch r {
+	case NotFinished:
+		return "not finished"
+	case BoardFull:
+		return "board full"
+	case BothBlocked:
+		return "both sides blocked"
+	case Wipeout:
+		return "wipeout"
+	default:
+		return fmt.Sprintf("EndReason(%d)", int(r))
+	}
+}
+
+// InitBoard is the standard Othello starting position: white on d4 and e5,
+// black on d5 and e4.
 var InitBoard = [][]int{
 	{0, 0, 0, 0, 0, 0, 0, 0},
 	{0, 0, 0, 0, 0, 0, 0, 0},
 	{0, 0, 0, 0, 0, 0, 0, 0},
-	{0, 0, 0, 1, 2, 0, 0, 0},
 	{0, 0, 0, 2, 1, 0, 0, 0},
+	{0, 0, 0, 1, 2, 0, 0, 0},
 	{0, 0, 0, 0, 0, 0, 0, 0},
 	{0, 0, 0, 0, 0, 0, 0, 0},
 	{0, 0, 0, 0, 0, 0, 0, 0},
 }
 
-func NewGame() *Game {
-	board := NewBoard(InitBoard)
-	return &Game{board: board}
+// NewGame sets up a game in the Prepare state. Without options it uses the
+// standard 8x8 board with Black to move first.
+func NewGame(opts ...Option) (*Game, error) {
+	c, err := newGameConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Game{board: NewBoard(c.layout), first: c.first, rules: c.rules}, nil
+}
+
+// Start moves a prepared game to the first player's turn. If that player has
+// no legal move a pass is recorded, and if neither side can move the game
+// finishes straight away.
+func (game *Game) Start() {
+	if game.GameState != Prepare {
+		return
+	}
+	first := game.first
+	if !first.Valid() {
+		first = Black
+	}
+	game.advance(first.Opponent())
 }
 
+// Show prints the board to stdout in plain ASCII, marking the legal moves and
+// the last move.
 func (game *Game) Show() {
-	game.board.Show()
+	game.Render(os.Stdout)
+	fmt.Println("")
 }
 
 func (game *Game) GetBoard() [][]*Cell {
@@ -52,44 +141,106 @@ func (game *Game) IsOccupied() bool {
 	return game.board.IsOccupied()
 }
 
-func (game *Game) SetStone(color int, pos *Position) error {
-	if game.GameState != GameState(color) {
-		fmt.Printf("OutOfTurn: client: %d, server: %d\n", color, game.GameState)
-		return errors.New("OutOfTurn")
+func (game *Game) SetStone(color Color, pos *Position) error {
+	if err := game.checkTurn(color, pos); err != nil {
+		logReject(game.logger, color, pos, err)
+		return err
+	}
+	if err := game.Rules().Legal(game.board, color, pos); err != nil {
+		logReject(game.logger, color, pos, err)
+		return err
 	}
 
-	err := game.board.SetStone(color, pos)
+	flipped, err := game.board.play(color, pos)
 	if err != nil {
 		return err
 	}
-	if game.board.IsOccupied() {
-		game.updateGameState(Finish)
-		return nil
+	game.record(&Move{
+		Color:   color,
+		Pos:     &Position{X: pos.X, Y: pos.Y},
+		Flipped: flipped,
+		Before:  game.GameState,
+	})
+	game.advance(color)
+	return nil
+}
+
+func (game *Game) checkTurn(color Color, pos *Position) error {
+	if err := validateColor(color); err != nil {
+		return err
+	}
+	if game.GameState == Finish {
+		return illegalMove(color, pos, ErrGameFinished)
 	}
-	opponent := game.board.Opponent(color)
-	if len(game.board.ListAllocatablePositions(opponent)) > 0 {
-		game.updateGameState(GameState(opponent))
+	if game.GameState.Turn() != color {
+		return illegalMove(color, pos, ErrOutOfTurn)
 	}
 	return nil
 }
 
-func (game *Game) Winner() int {
-	bCount := game.board.Count(int(Black))
-	wCount := game.board.Count(int(White))
-	if bCount > wCount {
-		return 1
-	} else if bCount < wCount {
-		return 2
-	} else {
-		return 0
+// EndReason reports why the game finished, or NotFinished while it is running.
+func (game *Game) EndReason() EndReason {
+	return game.endReason
+}
+
+// Winner returns the winning color under the game rules, or None on a draw.
+func (game *Game) Winner() Color {
+	return game.Rules().Winner(game.board)
+}
+
+// Rules returns the rules the game is played under.
+func (game *Game) Rules() Rules {
+	return baseRules(game.rules)
+}
+
+// ListAllocatablePositions lists the moves color may play under the game
+// rules.
+func (game *Game) ListAllocatablePositions(color Color) []*Position {
+	positions := game.board.ListAllocatablePositions(color)
+	if _, ok := game.Rules().(Standard); ok {
+		return positions
 	}
+	ret := positions[:0]
+	for _, pos := range positions {
+		if game.Rules().Legal(game.board, color, pos) == nil {
+			ret = append(ret, pos)
+		}
+	}
+	return ret
 }
 
-func (game *Game) ListAllocatablePositions(color int) []*Position {
-	return game.board.ListAllocatablePositions(color)
+// advance hands the turn over after color has moved. When the opponent has no
+// legal move a pass is recorded for it.
+func (game *Game) advance(color Color) {
+	state, reason, pass := game.next(color)
+	if pass {
+		opponent := color.Opponent()
+		game.record(&Move{Color: opponent, Before: turnOf(opponent)})
+		logPass(game.logger, opponent)
+	}
+	game.endReason = reason
+	game.updateGameState(state)
+}
+
+// next works out the state following a move by color, and whether the
+// opponent has to pass. The game finishes when neither side can move.
+func (game *Game) next(color Color) (GameState, EndReason, bool) {
+	if reason := game.Rules().Finished(game.board); reason != NotFinished {
+		return Finish, reason, false
+	}
+	opponent := color.Opponent()
+	if len(game.ListAllocatablePositions(opponent)) > 0 {
+		return turnOf(opponent), NotFinished, false
+	}
+	if len(game.ListAllocatablePositions(color)) > 0 {
+		return turnOf(color), NotFinished, true
+	}
+	return Finish, BothBlocked, false
 }
 
 func (game *Game) updateGameState(s GameState) {
-	fmt.Printf("set phase: %d -> %d\n", game.GameState, s)
+	if game.GameState != s {
+		logPhase(game.logger, game.GameState, s, game.endReason)
+	}
 	game.GameState = s
 }
@@ -0,0 +1,456 @@
+package reversi
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestGame_SetStone(t *testing.T) {
+	testcases := []struct {
+		desc      string
+		board     [][]int
+		moves     []*Position
+		state     GameState
+		reason    EndReason
+		history   []*Move
+		wantError bool
+	}{
+		{
+			desc:    "when opponent can move",
+			board:   InitBoard,
+			moves:   []*Position{{X: 5, Y: 4}},
+			state:   WhiteTurn,
+			reason:  NotFinished,
+			history: []*Move{{Color: Black, Pos: &Position{X: 5, Y: 4}}},
+		},
+		{
+			desc: "when opponent has to pass",
+			board: [][]int{
+				{0, 2, 1, 0},
+				{0, 0, 0, 0},
+				{0, 0, 2, 1},
+				{0, 0, 0, 0},
+			},
+			moves:  []*Position{{X: 0, Y: 0}},
+			state:  BlackTurn,
+			reason: NotFinished,
+			history: []*Move{
+				{Color: Black, Pos: &Position{X: 0, Y: 0}},
+				{Color: White},
+			},
+		},
+		{
+			desc: "when opponent is wiped out",
+			board: [][]int{
+				{0, 2, 1, 0},
+				{0, 0, 0, 0},
+				{0, 0, 2, 1},
+				{0, 0, 0, 0},
+			},
+			moves:  []*Position{{X: 0, Y: 0}, {X: 1, Y: 2}},
+			state:  Finish,
+			reason: Wipeout,
+			history: []*Move{
+				{Color: Black, Pos: &Position{X: 0, Y: 0}},
+				{Color: White},
+				{Color: Black, Pos: &Position{X: 1, Y: 2}},
+			},
+		},
+		{
+			desc: "when neither side can move",
+			board: [][]int{
+				{0, 2, 1, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{2, 0, 0, 0},
+			},
+			moves:   []*Position{{X: 0, Y: 0}},
+			state:   Finish,
+			reason:  BothBlocked,
+			history: []*Move{{Color: Black, Pos: &Position{X: 0, Y: 0}}},
+		},
+		{
+			desc:    "when board is full",
+			board:   [][]int{{0, 2, 1}},
+			moves:   []*Position{{X: 0, Y: 0}},
+			state:   Finish,
+			reason:  BoardFull,
+			history: []*Move{{Color: Black, Pos: &Position{X: 0, Y: 0}}},
+		},
+		{
+			desc: "when move is illegal",
+			board: [][]int{
+				{0, 2, 1, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 2, 0, 0},
+			},
+			moves:     []*Position{{X: 3, Y: 3}},
+			state:     BlackTurn,
+			reason:    NotFinished,
+			history:   []*Move{},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		game := &Game{GameState: BlackTurn, board: NewBoard(tc.board)}
+		var err error
+		for _, pos := range tc.moves {
+			if game.GameState == Finish {
+				t.Fatalf("%s, game finished before (%d, %d)", tc.desc, pos.X, pos.Y)
+			}
+			err = game.SetStone(game.GameState.Turn(), pos)
+		}
+		if tc.wantError != (err != nil) {
+			t.Errorf("%s, unexpected error: %v", tc.desc, err)
+		}
+		if game.GameState != tc.state {
+			t.Errorf("%s, state got: %d, expected: %d", tc.desc, game.GameState, tc.state)
+		}
+		if game.EndReason() != tc.reason {
+			t.Errorf("%s, end reason got: %d, expected: %d", tc.desc, game.EndReason(), tc.reason)
+		}
+		history := game.History()
+		if len(history) != len(tc.history) {
+			t.Errorf("%s, history length got: %d, expected: %d", tc.desc, len(history), len(tc.history))
+			continue
+		}
+		for i, m := range history {
+			expected := tc.history[i]
+			if m.Color != expected.Color || m.IsPass() != expected.IsPass() {
+				t.Errorf("%s, history[%d] got: %+v, expected: %+v", tc.desc, i, m, expected)
+			} else if !m.IsPass() && *m.Pos != *expected.Pos {
+				t.Errorf("%s, history[%d] got: %v, expected: %v", tc.desc, i, m.Pos, expected.Pos)
+			}
+		}
+	}
+}
+
+func TestGame_UndoRedo(t *testing.T) {
+	type snapshot struct {
+		ply    int
+		board  [][]int
+		state  GameState
+		reason EndReason
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for _, init := range [][][]int{InitBoard, {
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 1, 2, 0, 0},
+		{0, 0, 2, 1, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+	}} {
+		game := &Game{GameState: BlackTurn, board: NewBoard(init)}
+		take := func() snapshot {
+			return snapshot{ply: game.Ply(), board: game.board.toArray(), state: game.GameState, reason: game.EndReason()}
+		}
+		check := func(desc string, expected snapshot) {
+			actual := take()
+			if actual.ply != expected.ply || actual.state != expected.state || actual.reason != expected.reason || !matchArray(actual.board, expected.board) {
+				t.Fatalf("%s, got: %+v, expected: %+v", desc, actual, expected)
+			}
+		}
+
+		snapshots := []snapshot{take()}
+		for game.GameState != Finish {
+			color := game.GameState.Turn()
+			moves := game.ListAllocatablePositions(color)
+			if err := game.SetStone(color, moves[rnd.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+			snapshots = append(snapshots, take())
+		}
+
+		for i := len(snapshots) - 2; i >= 0; i-- {
+			if !game.Undo() {
+				t.Fatalf("undo %d failed", i)
+			}
+			check("undo", snapshots[i])
+		}
+		if game.Undo() {
+			t.Errorf("undo succeeded at the beginning")
+		}
+		for i := 1; i < len(snapshots); i++ {
+			if !game.Redo() {
+				t.Fatalf("redo %d failed", i)
+			}
+			check("redo", snapshots[i])
+		}
+		if game.Redo() {
+			t.Errorf("redo succeeded at the end")
+		}
+
+		for _, i := range []int{0, len(snapshots) / 2, len(snapshots) - 1, 1} {
+			if err := game.JumpTo(snapshots[i].ply); err != nil {
+				t.Fatal(err)
+			}
+			check("jump", snapshots[i])
+		}
+		if err := game.JumpTo(len(game.history) + 1); err == nil {
+			t.Errorf("jump beyond history succeeded")
+		}
+
+		if err := game.JumpTo(0); err != nil {
+			t.Fatal(err)
+		}
+		if err := game.SetStone(Black, game.ListAllocatablePositions(Black)[0]); err != nil {
+			t.Fatal(err)
+		}
+		if game.Redo() || len(game.History()) != 1 {
+			t.Errorf("new move kept the undone moves, history: %d", len(game.history))
+		}
+	}
+}
+
+func TestGame_SetStone_errors(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		state  GameState
+		color  Color
+		pos    *Position
+		reason error
+	}{
+		{
+			desc:   "when out of bounds",
+			state:  BlackTurn,
+			color:  Black,
+			pos:    &Position{X: 8, Y: 3},
+			reason: ErrOutOfBounds,
+		},
+		{
+			desc:   "when cell is occupied",
+			state:  BlackTurn,
+			color:  Black,
+			pos:    &Position{X: 3, Y: 3},
+			reason: ErrOccupied,
+		},
+		{
+			desc:   "when nothing is flipped",
+			state:  BlackTurn,
+			color:  Black,
+			pos:    &Position{X: 0, Y: 0},
+			reason: ErrNoFlips,
+		},
+		{
+			desc:   "when out of turn",
+			state:  WhiteTurn,
+			color:  Black,
+			pos:    &Position{X: 5, Y: 4},
+			reason: ErrOutOfTurn,
+		},
+		{
+			desc:   "when game is finished",
+			state:  Finish,
+			color:  Black,
+			pos:    &Position{X: 5, Y: 4},
+			reason: ErrGameFinished,
+		},
+	}
+
+	for _, tc := range testcases {
+		game := &Game{GameState: tc.state, board: NewBoard(InitBoard)}
+		err := game.SetStone(tc.color, tc.pos)
+		if !errors.Is(err, tc.reason) {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, err, tc.reason)
+			continue
+		}
+		var moveErr *IllegalMoveError
+		if !errors.As(err, &moveErr) {
+			t.Errorf("%s, got: %T, expected: *IllegalMoveError", tc.desc, err)
+			continue
+		}
+		if moveErr.Pos != *tc.pos || moveErr.Color != tc.color {
+			t.Errorf("%s, got: %+v", tc.desc, moveErr)
+		}
+	}
+}
+
+func TestGame_SetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	game := &Game{GameState: BlackTurn, board: NewBoard(InitBoard)}
+	game.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if err := game.SetStone(Black, &Position{X: 5, Y: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.SetStone(Black, &Position{X: 5, Y: 5}); err == nil {
+		t.Fatal("out of turn move accepted")
+	}
+	if err := game.SetStone(White, &Position{X: 0, Y: 0}); err == nil {
+		t.Fatal("illegal move accepted")
+	}
+
+	expected := []string{
+		`msg=move color=black x=5 y=4 flips=1`,
+		`msg=phase from="black turn" to="white turn"`,
+		`msg="move rejected" color=black x=5 y=5 error="Illegal move (5, 5) by black: out of turn"`,
+		`msg="move rejected" color=white x=0 y=0 error="Illegal move (0, 0) by white: no discs to flip"`,
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("got %d events, expected %d:\n%s", len(lines), len(expected), buf.String())
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, expected[i]) {
+			t.Errorf("event %d, got: %s, expected: %s", i, line, expected[i])
+		}
+	}
+}
+
+func TestGame_Clone(t *testing.T) {
+	game := &Game{GameState: BlackTurn, board: NewBoard(InitBoard)}
+	if err := game.SetStone(Black, &Position{X: 5, Y: 4}); err != nil {
+		t.Fatal(err)
+	}
+	clone := game.Clone()
+	if !clone.board.Equal(game.board) || clone.GameState != game.GameState || clone.Ply() != game.Ply() {
+		t.Fatalf("clone differs from original")
+	}
+
+	if !clone.Undo() {
+		t.Fatal("undo on clone failed")
+	}
+	if err := clone.SetStone(Black, &Position{X: 4, Y: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if game.Ply() != 1 || game.GameState != WhiteTurn {
+		t.Errorf("clone changed the original, ply: %d, state: %s", game.Ply(), game.GameState)
+	}
+	if m := game.History()[0]; *m.Pos != (Position{X: 5, Y: 4}) {
+		t.Errorf("clone changed the original history: %v", m.Pos)
+	}
+	if game.board.Cell(5, 4).State != Black || game.board.Cell(4, 5).State != None {
+		t.Errorf("clone changed the original board")
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		opts     []Option
+		expected [][]int
+		state    GameState
+		history  int
+		err      error
+	}{
+		{
+			desc:     "when default",
+			expected: InitBoard,
+			state:    BlackTurn,
+		},
+		{
+			desc: "when 6x6",
+			opts: []Option{WithSize(6)},
+			expected: [][]int{
+				{0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0},
+				{0, 0, 2, 1, 0, 0},
+				{0, 0, 1, 2, 0, 0},
+				{0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0},
+			},
+			state: BlackTurn,
+		},
+		{
+			desc:     "when white moves first",
+			opts:     []Option{WithFirstPlayer(White)},
+			expected: InitBoard,
+			state:    WhiteTurn,
+		},
+		{
+			desc: "when first player has to pass",
+			opts: []Option{WithLayout([][]int{
+				{0, 2, 1, 1},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			}), WithFirstPlayer(White)},
+			expected: [][]int{
+				{0, 2, 1, 1},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+			state:   BlackTurn,
+			history: 1,
+		},
+		{
+			desc: "when layout is ragged",
+			opts: []Option{WithLayout([][]int{
+				{0, 0, 0, 0},
+				{0, 1, 2},
+				{0, 2, 1, 0},
+				{0, 0, 0, 0},
+			})},
+			err: ErrLayout,
+		},
+		{
+			desc: "when layout has unknown state",
+			opts: []Option{WithLayout([][]int{
+				{0, 0, 0, 0},
+				{0, 1, 2, 0},
+				{0, 2, 5, 0},
+				{0, 0, 0, 0},
+			})},
+			err: ErrLayout,
+		},
+		{
+			desc: "when layout is empty",
+			opts: []Option{WithLayout([][]int{})},
+			err:  ErrLayout,
+		},
+		{
+			desc: "when size is odd",
+			opts: []Option{WithSize(7)},
+			err:  ErrInvalidSize,
+		},
+		{
+			desc: "when size is too small",
+			opts: []Option{WithSize(2)},
+			err:  ErrInvalidSize,
+		},
+		{
+			desc: "when first player is invalid",
+			opts: []Option{WithFirstPlayer(None)},
+			err:  ErrInvalidColor,
+		},
+	}
+
+	for _, tc := range testcases {
+		game, err := NewGame(tc.opts...)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%s, got: %v, expected: %v", tc.desc, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s, unexpected error: %v", tc.desc, err)
+			continue
+		}
+		if game.GameState != Prepare {
+			t.Errorf("%s, state before Start: %s", tc.desc, game.GameState)
+		}
+		game.Start()
+		if !matchArray(game.board.toArray(), tc.expected) {
+			t.Errorf("%s, unexpected board", tc.desc)
+			game.Show()
+		}
+		if game.GameState != tc.state {
+			t.Errorf("%s, state got: %s, expected: %s", tc.desc, game.GameState, tc.state)
+		}
+		if len(game.History()) != tc.history {
+			t.Errorf("%s, history got: %d, expected: %d", tc.desc, len(game.History()), tc.history)
+		}
+		if game.Undo() {
+			t.Errorf("%s, undo succeeded right after Start", tc.desc)
+		}
+	}
+}
@@ -0,0 +1,459 @@
+package reversi
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GGFRecord is a game in the Generic Game Format used by the GGS Othello
+// server, e.g.
+//
+//	(;GM[Othello]PB[alice]PW[bob]TY[8]RE[+2.000]BO[8 ... *]B[f5//1.20]W[d6/-2.00/3.00];)
+type GGFRecord struct {
+	Place       string // PC
+	Date        string // DT
+	Black       string // PB
+	White       string // PW
+	BlackRating string // RB
+	WhiteRating string // RW
+	TimeControl string // TI
+	Type        string // TY
+	Result      string // RE, disc difference from Black's point of view
+	Setup       [][]int
+	ToMove      Color
+	Moves       []GGFMove
+	Tags        map[string]string // any other tag, keyed by name
+}
+
+// GGFMove is a move of a GGF record. Pos is nil for a pass.
+type GGFMove struct {
+	Color   Color
+	Pos     *Position
+	Eval    float64
+	HasEval bool
+	Time    time.Duration
+}
+
+// NewGGFRecord builds a record from the history of game, up to its current
+// ply. Player names, dates and clocks are left for the caller to fill in.
+func NewGGFRecord(game *Game) *GGFRecord {
+	start := game.Clone()
+	start.JumpTo(0)
+	history := game.History()
+
+	rec := &GGFRecord{
+		Type:   strconv.Itoa(game.board.Width),
+		Setup:  start.board.toArray(),
+		ToMove: start.GameState.Turn(),
+	}
+	if len(history) > 0 {
+		rec.ToMove = history[0].Color
+	} else if !rec.ToMove.Valid() {
+		rec.ToMove = baseColor(game.first)
+	}
+	// Start records a pass when the first player cannot move; keep it.
+	for _, m := range start.History() {
+		rec.Moves = append(rec.Moves, GGFMove{Color: m.Color})
+	}
+	for _, m := range history[start.Ply():] {
+		rec.Moves = append(rec.Moves, GGFMove{Color: m.Color, Pos: m.Pos})
+	}
+	if _, ok := game.Rules().(AntiReversi); ok {
+		rec.Type += "a"
+	}
+	if game.GameState == Finish {
+		rec.Result = fmt.Sprintf("%+.3f", float64(game.board.Count(Black)-game.board.Count(White)))
+	}
+	return rec
+}
+
+// Game replays the record and returns the resulting game. A pass in the
+// record must match one the game inserts by itself; records that leave
+// passes out are accepted too. Anti-reversi games, marked by an "a" in the
+// type, are played under AntiReversi rules.
+func (rec *GGFRecord) Game(opts ...Option) (*Game, error) {
+	base := []Option{WithLayout(rec.Setup), WithFirstPlayer(baseColor(rec.ToMove))}
+	if strings.Contains(rec.Type, "a") {
+		base = append(base, WithRules(AntiReversi{}))
+	}
+	opts = append(base, opts...)
+	game, err := NewGame(opts...)
+	if err != nil {
+		return nil, err
+	}
+	game.Start()
+	passes := game.Ply()
+	for i, m := range rec.Moves {
+		if m.Pos == nil {
+			if passes == 0 {
+				return nil, fmt.Errorf("GGF move %d: %w: unexpected pass by %s", i+1, ErrGGF, m.Color)
+			}
+			passes--
+			continue
+		}
+		ply := game.Ply()
+		if err := game.SetStone(m.Color, m.Pos); err != nil {
+			return nil, fmt.Errorf("GGF move %d (%s): %w", i+1, m.Pos, err)
+		}
+		passes = game.Ply() - ply - 1
+	}
+	return game, nil
+}
+
+func (rec *GGFRecord) String() string {
+	var sb strings.Builder
+	sb.WriteString("(;GM[Othello]")
+	for _, tag := range []struct{ key, value string }{
+		{"PC", rec.Place},
+		{"DT", rec.Date},
+		{"PB", rec.Black},
+		{"PW", rec.White},
+		{"RB", rec.BlackRating},
+		{"RW", rec.WhiteRating},
+		{"TI", rec.TimeControl},
+		{"TY", rec.Type},
+		{"RE", rec.Result},
+	} {
+		writeGGFTag(&sb, tag.key, tag.value)
+	}
+	keys := make([]string, 0, len(rec.Tags))
+	for key := range rec.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeGGFTag(&sb, key, rec.Tags[key])
+	}
+	writeGGFTag(&sb, "BO", formatGGFBoard(rec.Setup, rec.ToMove))
+	for _, m := range rec.Moves {
+		key := "B"
+		if m.Color == White {
+			key = "W"
+		}
+		writeGGFTag(&sb, key, formatGGFMove(m))
+	}
+	sb.WriteString(";)")
+	return sb.String()
+}
+
+// WriteGGF writes rec followed by a newline.
+func WriteGGF(w io.Writer, rec *GGFRecord) error {
+	_, err := io.WriteString(w, rec.String()+"\n")
+	return err
+}
+
+// ParseGGF parses a single GGF record.
+func ParseGGF(s string) (*GGFRecord, error) {
+	return NewGGFReader(strings.NewReader(s)).Read()
+}
+
+// GGFReader reads GGF records one at a time from a stream, such as a game
+// archive with one record per line.
+type GGFReader struct {
+	r *bufio.Reader
+}
+
+func NewGGFReader(r io.Reader) *GGFReader {
+	return &GGFReader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next record, or io.EOF when the stream is exhausted.
+func (r *GGFReader) Read() (*GGFRecord, error) {
+	if err := r.skipTo("(;"); err != nil {
+		return nil, err
+	}
+	rec := &GGFRecord{ToMove: Black}
+	for {
+		c, err := r.nextNonSpace()
+		if err != nil {
+			return nil, ggfEOF(err)
+		}
+		if c == ';' {
+			if c, err = r.nextNonSpace(); err != nil {
+				return nil, ggfEOF(err)
+			}
+			if c != ')' {
+				return nil, fmt.Errorf("%w: expected ')' after ';'", ErrGGF)
+			}
+			if rec.Setup == nil {
+				return nil, fmt.Errorf("%w: missing BO tag", ErrGGF)
+			}
+			return rec, nil
+		}
+		key := []byte{c}
+		for {
+			if c, err = r.r.ReadByte(); err != nil {
+				return nil, ggfEOF(err)
+			}
+			if c == '[' {
+				break
+			}
+			key = append(key, c)
+		}
+		value, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+		if err := rec.setTag(strings.TrimSpace(string(key)), value); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *GGFReader) skipTo(marker string) error {
+	matched := 0
+	for matched < len(marker) {
+		c, err := r.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case c == marker[matched]:
+			matched++
+		case c == marker[0]:
+			matched = 1
+		default:
+			matched = 0
+		}
+	}
+	return nil
+}
+
+func (r *GGFReader) nextNonSpace() (byte, error) {
+	for {
+		c, err := r.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(c)) {
+			return c, nil
+		}
+	}
+}
+
+// value reads a tag value up to the closing bracket, honouring backslash
+// escapes.
+func (r *GGFReader) value() (string, error) {
+	var sb strings.Builder
+	for {
+		c, err := r.r.ReadByte()
+		if err != nil {
+			return "", ggfEOF(err)
+		}
+		switch c {
+		case ']':
+			return sb.String(), nil
+		case '\\':
+			if c, err = r.r.ReadByte(); err != nil {
+				return "", ggfEOF(err)
+			}
+		}
+		sb.WriteByte(c)
+	}
+}
+
+func (rec *GGFRecord) setTag(key, value string) error {
+	switch key {
+	case "GM":
+		if !strings.EqualFold(value, "Othello") {
+			return fmt.Errorf("%w: unsupported game %q", ErrGGF, value)
+		}
+	case "PC":
+		rec.Place = value
+	case "DT":
+		rec.Date = value
+	case "PB":
+		rec.Black = value
+	case "PW":
+		rec.White = value
+	case "RB":
+		rec.BlackRating = value
+	case "RW":
+		rec.WhiteRating = value
+	case "TI":
+		rec.TimeControl = value
+	case "TY":
+		rec.Type = value
+	case "RE":
+		rec.Result = value
+	case "BO":
+		setup, toMove, err := parseGGFBoard(value)
+		if err != nil {
+			return err
+		}
+		rec.Setup, rec.ToMove = setup, toMove
+	case "B", "W":
+		color := Black
+		if key == "W" {
+			color = White
+		}
+		m, err := parseGGFMove(color, value)
+		if err != nil {
+			return err
+		}
+		rec.Moves = append(rec.Moves, m)
+	default:
+		if rec.Tags == nil {
+			rec.Tags = map[string]string{}
+		}
+		rec.Tags[key] = value
+	}
+	return nil
+}
+
+func parseGGFBoard(value string) ([][]int, Color, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return nil, None, fmt.Errorf("%w: malformed BO %q", ErrGGF, value)
+	}
+	size, err := strconv.Atoi(fields[0])
+	if err != nil || size <= 0 {
+		return nil, None, fmt.Errorf("%w: malformed BO size %q", ErrGGF, fields[0])
+	}
+	cells := strings.Join(fields[1:], "")
+	if len(cells) != size*size+1 {
+		return nil, None, fmt.Errorf("%w: BO has %d cells, expected %d", ErrGGF, len(cells)-1, size*size)
+	}
+	layout := make([][]int, size)
+	for i := range layout {
+		layout[i] = make([]int, size)
+		for j := range layout[i] {
+			state, ok := ggfCells[cells[i*size+j]]
+			if !ok {
+				return nil, None, fmt.Errorf("%w: unknown BO cell %q", ErrGGF, cells[i*size+j])
+			}
+			layout[i][j] = int(state)
+		}
+	}
+	toMove, ok := ggfCells[cells[len(cells)-1]]
+	if !ok || !toMove.Valid() {
+		return nil, None, fmt.Errorf("%w: unknown BO color %q", ErrGGF, cells[len(cells)-1])
+	}
+	return layout, toMove, nil
+}
+
+func formatGGFBoard(layout [][]int, toMove Color) string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(len(layout)))
+	for _, line := range layout {
+		sb.WriteByte(' ')
+		for _, state := range line {
+			sb.WriteByte(ggfCellChar(Color(state)))
+		}
+	}
+	sb.WriteByte(' ')
+	sb.WriteByte(ggfCellChar(baseColor(toMove)))
+	return sb.String()
+}
+
+var ggfCells = map[byte]Color{
+	'-': None,
+	'*': Black,
+	'O': White,
+	'o': White,
+	'#': Wall,
+}
+
+func ggfCellChar(state Color) byte {
+	switch state {
+	case Black:
+		return '*'
+	case White:
+		return 'O'
+	case Wall:
+		return '#'
+	default:
+		return '-'
+	}
+}
+
+func parseGGFMove(color Color, value string) (GGFMove, error) {
+	m := GGFMove{Color: color}
+	parts := strings.Split(value, "/")
+	switch move := strings.ToLower(strings.TrimSpace(parts[0])); move {
+	case "pa", "pass":
+	default:
+		pos, err := ParsePosition(move)
+		if err != nil {
+			return m, fmt.Errorf("%w: move %q: %v", ErrGGF, value, err)
+		}
+		m.Pos = pos
+	}
+	if len(parts) > 1 && strings.TrimSpace(parts[1]) != "" {
+		eval, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return m, fmt.Errorf("%w: move %q: bad evaluation", ErrGGF, value)
+		}
+		m.Eval, m.HasEval = eval, true
+	}
+	if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
+		d, err := parseGGFClock(strings.TrimSpace(parts[2]))
+		if err != nil {
+			return m, fmt.Errorf("%w: move %q: bad time", ErrGGF, value)
+		}
+		m.Time = d
+	}
+	return m, nil
+}
+
+func formatGGFMove(m GGFMove) string {
+	s := "PA"
+	if m.Pos != nil {
+		s = m.Pos.String()
+	}
+	eval, clock := "", ""
+	if m.HasEval {
+		eval = strconv.FormatFloat(m.Eval, 'f', 2, 64)
+	}
+	if m.Time > 0 {
+		clock = strconv.FormatFloat(m.Time.Seconds(), 'f', 2, 64)
+	}
+	if eval == "" && clock == "" {
+		return s
+	}
+	return s + "/" + eval + "/" + clock
+}
+
+// parseGGFClock parses seconds, optionally preceded by minutes and hours
+// separated by colons.
+func parseGGFClock(s string) (time.Duration, error) {
+	var total float64
+	for _, part := range strings.Split(s, ":") {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("bad clock %q", s)
+		}
+		total = total*60 + v
+	}
+	return time.Duration(total * float64(time.Second)), nil
+}
+
+func writeGGFTag(sb *strings.Builder, key, value string) {
+	if value == "" {
+		return
+	}
+	sb.WriteString(key)
+	sb.WriteByte('[')
+	sb.WriteString(strings.NewReplacer(`\`, `\\`, `]`, `\]`).Replace(value))
+	sb.WriteByte(']')
+}
+
+func ggfEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: unexpected end of record", ErrGGF)
+	}
+	return err
+}
+
+func baseColor(c Color) Color {
+	if !c.Valid() {
+		return Black
+	}
+	return c
+}
@@ -0,0 +1,140 @@
+package reversi
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleGGF = "(;GM[Othello]PC[GGS/os]DT[2003.12.15_13:24:03.MST]PB[alice]PW[bob]RB[2197.58]RW[2199.14]" +
+	"TI[05:00//02:00]TY[8]RE[+18.000]BO[8 -------- -------- -------- ---O*--- ---*O--- -------- -------- -------- *]" +
+	"B[F5//0.01]W[d6/-4.00/3.38]B[c3]W[d3]B[c4/2.5/1:02.5];)"
+
+func TestParseGGF(t *testing.T) {
+	rec, err := ParseGGF(sampleGGF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Place != "GGS/os" || rec.Black != "alice" || rec.White != "bob" || rec.BlackRating != "2197.58" ||
+		rec.TimeControl != "05:00//02:00" || rec.Type != "8" || rec.Result != "+18.000" {
+		t.Errorf("unexpected header: %+v", rec)
+	}
+	if !matchArray(rec.Setup, InitBoard) || rec.ToMove != Black {
+		t.Errorf("unexpected setup: %v, to move: %s", rec.Setup, rec.ToMove)
+	}
+	expected := []GGFMove{
+		{Color: Black, Pos: &Position{X: 5, Y: 4}, Time: 10 * time.Millisecond},
+		{Color: White, Pos: &Position{X: 3, Y: 5}, Eval: -4, HasEval: true, Time: 3380 * time.Millisecond},
+		{Color: Black, Pos: &Position{X: 2, Y: 2}},
+		{Color: White, Pos: &Position{X: 3, Y: 2}},
+		{Color: Black, Pos: &Position{X: 2, Y: 3}, Eval: 2.5, HasEval: true, Time: 62500 * time.Millisecond},
+	}
+	if len(rec.Moves) != len(expected) {
+		t.Fatalf("got %d moves, expected %d", len(rec.Moves), len(expected))
+	}
+	for i, m := range rec.Moves {
+		e := expected[i]
+		if m.Color != e.Color || *m.Pos != *e.Pos || m.Eval != e.Eval || m.HasEval != e.HasEval || m.Time != e.Time {
+			t.Errorf("move %d, got: %+v, expected: %+v", i, m, e)
+		}
+	}
+
+	game, err := rec.Game()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Transcript() != "f5d6c3d3c4" || game.GameState != WhiteTurn {
+		t.Errorf("got: %s, state: %s", game.Transcript(), game.GameState)
+	}
+}
+
+func TestParseGGF_errors(t *testing.T) {
+	testcases := []struct {
+		desc  string
+		input string
+	}{
+		{desc: "when truncated", input: "(;GM[Othello]BO[8 -------- -------- -------- ---O*--- ---*O--- -------- -------- -------- *]B[f5"},
+		{desc: "when board is missing", input: "(;GM[Othello]B[f5];)"},
+		{desc: "when board is short", input: "(;GM[Othello]BO[8 -------- *];)"},
+		{desc: "when game is not othello", input: "(;GM[Go]BO[4 ---- -O*- -*O- ---- *];)"},
+		{desc: "when move is broken", input: "(;GM[Othello]BO[4 ---- -O*- -*O- ---- *]B[z];)"},
+	}
+	for _, tc := range testcases {
+		if _, err := ParseGGF(tc.input); !errors.Is(err, ErrGGF) {
+			t.Errorf("%s, got: %v, expected: ErrGGF", tc.desc, err)
+		}
+	}
+}
+
+func TestGGFReader(t *testing.T) {
+	input := "garbage\n" + sampleGGF + "\n\n" + strings.Replace(sampleGGF, "alice", "carol", 1) + "\n"
+	r := NewGGFReader(strings.NewReader(input))
+	for _, name := range []string{"alice", "carol"} {
+		rec, err := r.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rec.Black != name {
+			t.Errorf("got: %s, expected: %s", rec.Black, name)
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("got: %v, expected: io.EOF", err)
+	}
+}
+
+func TestNewGGFRecord(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	testcases := []struct {
+		desc string
+		opts []Option
+	}{
+		{desc: "when 8x8"},
+		{desc: "when 10x10", opts: []Option{WithSize(10)}},
+		{desc: "when anti-reversi", opts: []Option{WithRules(AntiReversi{})}},
+		{
+			desc: "when first player passes",
+			opts: []Option{WithLayout([][]int{
+				{0, 2, 1, 1},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			}), WithFirstPlayer(White)},
+		},
+	}
+	for _, tc := range testcases {
+		game, err := NewGame(tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.Start()
+		for game.GameState != Finish {
+			moves := game.ListAllocatablePositions(game.GameState.Turn())
+			if err := game.SetStone(game.GameState.Turn(), moves[rnd.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		rec := NewGGFRecord(game)
+		rec.Black, rec.White = "alice", "bob"
+		rec.Moves[0].Eval, rec.Moves[0].HasEval = 1.5, true
+		rec.Moves[0].Time = 1250 * time.Millisecond
+		parsed, err := ParseGGF(rec.String())
+		if err != nil {
+			t.Fatalf("%s, %v\n%s", tc.desc, err, rec)
+		}
+		if parsed.String() != rec.String() {
+			t.Errorf("%s, round trip differs\ngot:      %s\nexpected: %s", tc.desc, parsed, rec)
+		}
+		replayed, err := parsed.Game()
+		if err != nil {
+			t.Fatalf("%s, %v", tc.desc, err)
+		}
+		if !replayed.board.Equal(game.board) || replayed.Ply() != game.Ply() || replayed.Winner() != game.Winner() {
+			t.Errorf("%s, replayed game differs", tc.desc)
+		}
+	}
+}
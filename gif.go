@@ -0,0 +1,91 @@
+package reversi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// WithFrameDelay sets how long each frame of a GIF replay is shown. The
+// default is 800ms; the final frame is shown for three times as long.
+func WithFrameDelay(d time.Duration) RenderOption {
+	return func(c *renderConfig) {
+		c.delay = d
+	}
+}
+
+// GIF writes an animated replay of the game up to the current ply to w. It
+// has a frame for the starting position, one per history entry with the
+// flipped discs highlighted, and a final frame with the score. Each frame is
+// drawn like Image; the options are applied to every frame.
+func (game *Game) GIF(w io.Writer, opts ...RenderOption) error {
+	start := game.Clone()
+	start.logger = nil
+	start.board.logger = nil
+	start.JumpTo(0)
+	b := start.board
+
+	c := &renderConfig{delay: 800 * time.Millisecond}
+	for _, opt := range opts {
+		opt(c)
+	}
+	delay := int(c.delay / (10 * time.Millisecond))
+	if delay < 1 {
+		delay = 1
+	}
+
+	anim := &gif.GIF{}
+	frame := func(caption string, delay int, marks ...RenderOption) {
+		marks = append([]RenderOption{withMoves([]*Position{}), withCaption(caption)}, marks...)
+		anim.Image = append(anim.Image, paletted(b.Image(append(marks, opts...)...)))
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	frame("start", delay)
+	numbers := map[Position]int{}
+	n := 0
+	for _, m := range game.History() {
+		if m.IsPass() {
+			frame(fmt.Sprintf("%s passes", m.Color), delay, withNumbers(numbers))
+			continue
+		}
+		b.replay(m.Color, m.Pos, m.Flipped)
+		n++
+		numbers[*m.Pos] = n
+		frame(fmt.Sprintf("%d %s %s", n, m.Color, m.Pos), delay,
+			WithLastMove(m.Pos), withFlipped(m.Flipped), withNumbers(numbers))
+	}
+	frame(fmt.Sprintf("black %d white %d", b.Count(Black), b.Count(White)), 3*delay, withNumbers(numbers))
+	return gif.EncodeAll(w, anim)
+}
+
+// gifPalette holds the image colours and the blends between them that
+// antialiased edges produce.
+var gifPalette = func() color.Palette {
+	base := []color.RGBA{
+		imageBackground, imageBoard, imageLine, imageWall, imageBlack,
+		imageWhite, imageLegal, imageMarker, imageLabel, imageFlipped,
+	}
+	p := color.Palette{}
+	for _, c := range base {
+		p = append(p, c)
+	}
+	for i, c1 := range base {
+		for _, c2 := range base[i+1:] {
+			for _, a := range []float64{0.25, 0.5, 0.75} {
+				p = append(p, blend(c1, c2, a))
+			}
+		}
+	}
+	return p
+}()
+
+func paletted(img *image.RGBA) *image.Paletted {
+	ret := image.NewPaletted(img.Bounds(), gifPalette)
+	draw.Draw(ret, ret.Bounds(), img, image.Point{}, draw.Src)
+	return ret
+}
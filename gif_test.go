@@ -0,0 +1,69 @@
+package reversi
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+	"time"
+)
+
+func TestGame_GIF(t *testing.T) {
+	moves, _ := ParseTranscript("f5d6")
+	pass, _ := NewGame(WithLayout([][]int{
+		{0, 2, 1, 1},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+	}), WithFirstPlayer(White))
+	pass.Start()
+
+	testcases := []struct {
+		desc   string
+		game   *Game
+		opts   []RenderOption
+		delays []int
+	}{
+		{desc: "when moves are played", game: moves, delays: []int{80, 80, 80, 240}},
+		{desc: "when first player passes", game: pass, delays: []int{80, 80, 240}},
+		{desc: "when delay is set", game: moves, opts: []RenderOption{WithFrameDelay(time.Second)}, delays: []int{100, 100, 100, 300}},
+	}
+	for _, tc := range testcases {
+		var buf bytes.Buffer
+		if err := tc.game.GIF(&buf, tc.opts...); err != nil {
+			t.Fatal(err)
+		}
+		anim, err := gif.DecodeAll(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(anim.Delay) != len(tc.delays) {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, anim.Delay, tc.delays)
+			continue
+		}
+		for i := range tc.delays {
+			if anim.Delay[i] != tc.delays[i] {
+				t.Errorf("%s, got: %v, expected: %v", tc.desc, anim.Delay, tc.delays)
+				break
+			}
+		}
+	}
+}
+
+func TestGame_GIF_flipped(t *testing.T) {
+	game, _ := ParseTranscript("f5")
+	var buf bytes.Buffer
+	if err := game.GIF(&buf, WithCoordinates(false)); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The ring around the disc flipped on e5 is just outside its radius.
+	r, g, b, _ := anim.Image[1].At(4*40+20+18, 4*40+20).RGBA()
+	actual := [3]uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)}
+	expected := [3]uint8{imageFlipped.R, imageFlipped.G, imageFlipped.B}
+	if actual != expected {
+		t.Errorf("got: %v, expected: %v", actual, expected)
+	}
+}
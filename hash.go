@@ -0,0 +1,39 @@
+package reversi
+
+import (
+	"github.com/myoan/go-reversi/internal/zobrist"
+)
+
+// Hash returns the Zobrist hash of the cells of the board. It is kept up to
+// date as moves are played and undone, so it costs nothing to call.
+func (b *Board) Hash() uint64 {
+	return b.hash
+}
+
+// HashTurn returns the hash of the board with color to move, so that the
+// same cells with different sides to move hash differently.
+func (b *Board) HashTurn(color Color) uint64 {
+	if color == White {
+		return b.hash ^ zobrist.Turn
+	}
+	return b.hash
+}
+
+// Hash returns the hash of the game position, including the side to move.
+func (game *Game) Hash() uint64 {
+	return game.board.HashTurn(game.GameState.Turn())
+}
+
+func (b *Board) cellKey(x, y int, state Color) uint64 {
+	return zobrist.Key(y*b.Width+x, int(state))
+}
+
+func (b *Board) computeHash() uint64 {
+	var ret uint64
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			ret ^= b.cellKey(j, i, b.cell(j, i).State)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,71 @@
+package reversi
+
+import (
+	"testing"
+)
+
+func TestBoard_Hash(t *testing.T) {
+	testcases := []struct {
+		desc  string
+		first string
+		other string
+		same  bool
+	}{
+		{desc: "when the same moves", first: "f5d6", other: "f5d6", same: true},
+		{desc: "when reaching the same cells", first: "d3c3f5f6", other: "f5f6d3c3", same: true},
+		{desc: "when different moves", first: "f5d6", other: "f5f6", same: false},
+	}
+	for _, tc := range testcases {
+		g1, err := ParseTranscript(tc.first)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g2, err := ParseTranscript(tc.other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g1.board.Equal(g2.board) != tc.same {
+			t.Fatalf("%s, boards equal: %v", tc.desc, !tc.same)
+		}
+		if (g1.Hash() == g2.Hash()) != tc.same {
+			t.Errorf("%s, got: %x and %x, expected same: %v", tc.desc, g1.Hash(), g2.Hash(), tc.same)
+		}
+	}
+}
+
+func TestBoard_Hash_incremental(t *testing.T) {
+	game, err := ParseTranscript("f5d6c3d3c4f4c5b3c2e6c6b4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := newGridBoard(InitBoard)
+	hashes := []uint64{game.board.computeHash()}
+	for _, m := range game.History() {
+		if !m.IsPass() {
+			grid.SetStone(m.Color, m.Pos)
+		}
+	}
+	if grid.Hash() != game.board.Hash() || game.board.Hash() != game.board.computeHash() {
+		t.Errorf("got: grid %x bitboard %x, expected: %x", grid.Hash(), game.board.Hash(), game.board.computeHash())
+	}
+	for game.Undo() {
+		hashes = append(hashes, game.board.Hash())
+		if game.board.Hash() != game.board.computeHash() {
+			t.Errorf("ply %d, got: %x, expected: %x", game.Ply(), game.board.Hash(), game.board.computeHash())
+		}
+	}
+	if hashes[len(hashes)-1] != NewBoard(InitBoard).Hash() {
+		t.Errorf("got: %x, expected the starting hash", hashes[len(hashes)-1])
+	}
+	clone := game.board.Clone()
+	if clone.Hash() != game.board.Hash() {
+		t.Errorf("clone got: %x, expected: %x", clone.Hash(), game.board.Hash())
+	}
+}
+
+func TestBoard_HashTurn(t *testing.T) {
+	b := NewBoard(InitBoard)
+	if b.HashTurn(Black) != b.Hash() || b.HashTurn(White) == b.Hash() {
+		t.Errorf("got: black %x white %x, board %x", b.HashTurn(Black), b.HashTurn(White), b.Hash())
+	}
+}
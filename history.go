@@ -0,0 +1,102 @@
+package reversi
+
+import (
+	"fmt"
+)
+
+// Move is an entry of the game history. Pos is nil when Color passed.
+type Move struct {
+	Color   Color       `json:"color"`
+	Pos     *Position   `json:"pos"`
+	Flipped []*Position `json:"flipped,omitempty"` // discs turned over by the move
+	Before  GameState   `json:"before"`            // game state before the move was played
+}
+
+func (m *Move) IsPass() bool {
+	return m.Pos == nil
+}
+
+// History returns the moves played up to the current ply, including passes.
+func (game *Game) History() []*Move {
+	ret := make([]*Move, game.ply)
+	copy(ret, game.history[:game.ply])
+	return ret
+}
+
+// Ply returns the number of history entries played to reach the current
+// position.
+func (game *Game) Ply() int {
+	return game.ply
+}
+
+// Undo takes back the last move, together with any pass that followed it.
+// It returns false when there is nothing to undo.
+func (game *Game) Undo() bool {
+	if game.ply <= game.leadingPasses() {
+		return false
+	}
+	for game.ply > 0 {
+		game.ply--
+		m := game.history[game.ply]
+		if !m.IsPass() {
+			game.board.unplay(m.Color, m.Pos, m.Flipped)
+		}
+		game.endReason = NotFinished
+		game.updateGameState(m.Before)
+		if !m.IsPass() {
+			break
+		}
+	}
+	return true
+}
+
+// Redo plays the next undone move again, together with any pass that
+// followed it. It returns false when there is nothing to redo.
+func (game *Game) Redo() bool {
+	if game.ply == len(game.history) {
+		return false
+	}
+	m := game.history[game.ply]
+	game.ply++
+	if !m.IsPass() {
+		game.board.replay(m.Color, m.Pos, m.Flipped)
+	}
+	state, reason, _ := game.next(m.Color)
+	for game.ply < len(game.history) && game.history[game.ply].IsPass() {
+		game.ply++
+	}
+	game.endReason = reason
+	game.updateGameState(state)
+	return true
+}
+
+// JumpTo moves back or forth through the history until ply entries have been
+// played. A ply that would stop right before a pass moves past the pass.
+// Jumping to ply 0 stops after any pass recorded when the game started.
+func (game *Game) JumpTo(ply int) error {
+	if ply < 0 || ply > len(game.history) {
+		return fmt.Errorf("ply %d out of range [0, %d]", ply, len(game.history))
+	}
+	for game.ply > ply && game.Undo() {
+	}
+	for game.ply < ply && game.Redo() {
+	}
+	return nil
+}
+
+// leadingPasses counts the passes recorded before the first move, which
+// happen when the first player cannot move at the start. Undo never goes back
+// past them.
+func (game *Game) leadingPasses() int {
+	n := 0
+	for n < len(game.history) && game.history[n].IsPass() {
+		n++
+	}
+	return n
+}
+
+// record appends m at the current ply, discarding any undone moves.
+func (game *Game) record(m *Move) {
+	game.history = append(game.history[:game.ply], m)
+	game.ply++
+}
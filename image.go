@@ -0,0 +1,366 @@
+package reversi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+)
+
+// WithCellSize sets the width of a cell in pixels for SVG and PNG images.
+// The default is 40.
+func WithCellSize(px int) RenderOption {
+	return func(c *renderConfig) {
+		c.cellSize = px
+	}
+}
+
+// WithMoveNumbers writes on each disc the number of the move that placed it.
+// Only games know their moves, so boards ignore it. Text rendering ignores it
+// too.
+func WithMoveNumbers(on bool) RenderOption {
+	return func(c *renderConfig) {
+		c.moveNumbers = on
+	}
+}
+
+// withNumbers supplies the move numbers drawn by WithMoveNumbers.
+func withNumbers(numbers map[Position]int) RenderOption {
+	return func(c *renderConfig) {
+		c.numbers = numbers
+	}
+}
+
+// withFlipped rings the discs on flipped.
+func withFlipped(flipped []*Position) RenderOption {
+	return func(c *renderConfig) {
+		c.flipped = flipped
+	}
+}
+
+// withCaption writes s under the board.
+func withCaption(s string) RenderOption {
+	return func(c *renderConfig) {
+		c.caption = s
+	}
+}
+
+var (
+	imageBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	imageBoard      = color.RGBA{0x2e, 0x7d, 0x4f, 0xff}
+	imageLine       = color.RGBA{0x1b, 0x4d, 0x31, 0xff}
+	imageWall       = color.RGBA{0x77, 0x77, 0x77, 0xff}
+	imageBlack      = color.RGBA{0x11, 0x11, 0x11, 0xff}
+	imageWhite      = color.RGBA{0xf5, 0xf5, 0xf5, 0xff}
+	imageLegal      = color.RGBA{0x1b, 0x4d, 0x31, 0xff}
+	imageMarker     = color.RGBA{0xe0, 0x3c, 0x31, 0xff}
+	imageLabel      = color.RGBA{0x44, 0x44, 0x44, 0xff}
+	imageFlipped    = color.RGBA{0xff, 0xa5, 0x00, 0xff}
+)
+
+// boardImage holds what both image formats draw: the geometry and, for each
+// cell, its state and marks.
+type boardImage struct {
+	board   *Board
+	cfg     *renderConfig
+	legal   map[Position]bool
+	flipped map[Position]bool
+	cell    int
+	margin  int
+	caption int // top of the caption strip, or 0 without a caption
+	width   int
+	height  int
+}
+
+func newBoardImage(b *Board, opts []RenderOption) *boardImage {
+	c := &renderConfig{coordinates: true, cellSize: 40}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.cellSize < 8 {
+		c.cellSize = 8
+	}
+	moves := c.moves
+	if moves == nil {
+		moves = b.ListAllocatablePositions(c.legal)
+	}
+	legal := map[Position]bool{}
+	for _, pos := range moves {
+		legal[*pos] = true
+	}
+	flipped := map[Position]bool{}
+	for _, pos := range c.flipped {
+		flipped[*pos] = true
+	}
+	img := &boardImage{board: b, cfg: c, legal: legal, flipped: flipped, cell: c.cellSize}
+	if c.coordinates {
+		img.margin = c.cellSize * 3 / 5
+	}
+	img.width = b.Width*img.cell + 2*img.margin
+	img.height = b.Height*img.cell + 2*img.margin
+	if c.caption != "" {
+		img.caption = img.height
+		img.height += c.cellSize
+	}
+	return img
+}
+
+// center returns the pixel at the centre of cell (x, y).
+func (img *boardImage) center(x, y int) (int, int) {
+	return img.margin + x*img.cell + img.cell/2, img.margin + y*img.cell + img.cell/2
+}
+
+func (img *boardImage) discRadius() float64 {
+	return float64(img.cell) * 0.42
+}
+
+func (img *boardImage) dotRadius() float64 {
+	return float64(img.cell) * 0.1
+}
+
+func (img *boardImage) number(pos Position) string {
+	if !img.cfg.moveNumbers {
+		return ""
+	}
+	if n, ok := img.cfg.numbers[pos]; ok {
+		return fmt.Sprint(n)
+	}
+	return ""
+}
+
+func (img *boardImage) isLast(pos Position) bool {
+	return img.cfg.last != nil && *img.cfg.last == pos
+}
+
+// SVG writes the board to w as an SVG image. Cells with unknown states are
+// left empty.
+func (b *Board) SVG(w io.Writer, opts ...RenderOption) error {
+	return newBoardImage(b, opts).writeSVG(w)
+}
+
+// Image draws the board. Cells with unknown states are left empty.
+func (b *Board) Image(opts ...RenderOption) *image.RGBA {
+	return newBoardImage(b, opts).draw()
+}
+
+// PNG writes the image drawn by Image to w.
+func (b *Board) PNG(w io.Writer, opts ...RenderOption) error {
+	return png.Encode(w, b.Image(opts...))
+}
+
+// SVG writes the game board to w as an SVG image, with the same marks as
+// Render. Move numbers are drawn when WithMoveNumbers is given.
+func (game *Game) SVG(w io.Writer, opts ...RenderOption) error {
+	return game.board.SVG(w, append(game.renderOptions(), opts...)...)
+}
+
+// Image draws the game board with the same marks as SVG.
+func (game *Game) Image(opts ...RenderOption) *image.RGBA {
+	return game.board.Image(append(game.renderOptions(), opts...)...)
+}
+
+// PNG writes the image drawn by Image to w.
+func (game *Game) PNG(w io.Writer, opts ...RenderOption) error {
+	return png.Encode(w, game.Image(opts...))
+}
+
+func (img *boardImage) writeSVG(w io.Writer) error {
+	b := img.board
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		img.width, img.height, img.width, img.height)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="%s"/>`+"\n", img.width, img.height, svgColor(imageBackground))
+	fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+		img.margin, img.margin, b.Width*img.cell, b.Height*img.cell, svgColor(imageBoard))
+
+	font := img.cell * 2 / 5
+	text := func(s string, x, y int, c color.RGBA) {
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" font-family="sans-serif" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+			x, y, font, svgColor(c), s)
+	}
+	if img.cfg.coordinates {
+		for j := 0; j < b.Width; j++ {
+			x, _ := img.center(j, 0)
+			text(columnName(j), x, img.margin/2, imageLabel)
+		}
+		for i := 0; i < b.Height; i++ {
+			_, y := img.center(0, i)
+			text(fmt.Sprint(i+1), img.margin/2, y, imageLabel)
+		}
+	}
+	for i := 0; i <= b.Height; i++ {
+		y := img.margin + i*img.cell
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n",
+			img.margin, y, img.margin+b.Width*img.cell, y, svgColor(imageLine))
+	}
+	for j := 0; j <= b.Width; j++ {
+		x := img.margin + j*img.cell
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n",
+			x, img.margin, x, img.margin+b.Height*img.cell, svgColor(imageLine))
+	}
+
+	if img.caption > 0 {
+		text(img.cfg.caption, img.width/2, img.caption+img.cell/2, imageLabel)
+	}
+
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			pos := Position{X: j, Y: i}
+			cx, cy := img.center(j, i)
+			state := b.Cell(j, i).State
+			switch state {
+			case Wall:
+				fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					img.margin+j*img.cell, img.margin+i*img.cell, img.cell, img.cell, svgColor(imageWall))
+			case Black, White:
+				fill, ink := imageBlack, imageWhite
+				if state == White {
+					fill, ink = imageWhite, imageBlack
+				}
+				stroke, width := imageBlack, 1
+				if img.flipped[pos] {
+					stroke, width = imageFlipped, 3
+				}
+				fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="%.1f" fill="%s" stroke="%s" stroke-width="%d"/>`+"\n",
+					cx, cy, img.discRadius(), svgColor(fill), svgColor(stroke), width)
+				if n := img.number(pos); n != "" {
+					if img.isLast(pos) {
+						ink = imageMarker
+					}
+					text(n, cx, cy, ink)
+					continue
+				}
+			case None:
+				if img.legal[pos] {
+					fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="%.1f" fill="%s"/>`+"\n",
+						cx, cy, img.dotRadius(), svgColor(imageLegal))
+				}
+			}
+			if img.isLast(pos) {
+				fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="%.1f" fill="%s"/>`+"\n",
+					cx, cy, img.dotRadius(), svgColor(imageMarker))
+			}
+		}
+	}
+	sb.WriteString("</svg>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (img *boardImage) draw() *image.RGBA {
+	b := img.board
+	ret := image.NewRGBA(image.Rect(0, 0, img.width, img.height))
+	fillRect(ret, ret.Bounds(), imageBackground)
+	area := image.Rect(img.margin, img.margin, img.margin+b.Width*img.cell, img.margin+b.Height*img.cell)
+	fillRect(ret, area, imageBoard)
+
+	scale := img.cell / 20
+	if scale < 1 {
+		scale = 1
+	}
+	if img.cfg.coordinates {
+		for j := 0; j < b.Width; j++ {
+			x, _ := img.center(j, 0)
+			drawText(ret, columnName(j), x, img.margin/2, scale, imageLabel)
+		}
+		for i := 0; i < b.Height; i++ {
+			_, y := img.center(0, i)
+			drawText(ret, fmt.Sprint(i+1), img.margin/2, y, scale, imageLabel)
+		}
+	}
+	if img.caption > 0 {
+		drawText(ret, img.cfg.caption, img.width/2, img.caption+img.cell/2, scale, imageLabel)
+	}
+	for i := 0; i <= b.Height; i++ {
+		y := img.margin + i*img.cell
+		fillRect(ret, image.Rect(area.Min.X, y, area.Max.X+1, y+1), imageLine)
+	}
+	for j := 0; j <= b.Width; j++ {
+		x := img.margin + j*img.cell
+		fillRect(ret, image.Rect(x, area.Min.Y, x+1, area.Max.Y+1), imageLine)
+	}
+
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			pos := Position{X: j, Y: i}
+			cx, cy := img.center(j, i)
+			state := b.Cell(j, i).State
+			switch state {
+			case Wall:
+				x, y := img.margin+j*img.cell, img.margin+i*img.cell
+				fillRect(ret, image.Rect(x+1, y+1, x+img.cell, y+img.cell), imageWall)
+			case Black, White:
+				fill, ink := imageBlack, imageWhite
+				if state == White {
+					fill, ink = imageWhite, imageBlack
+				}
+				if img.flipped[pos] {
+					fillCircle(ret, cx, cy, img.discRadius()+3, imageFlipped)
+				} else {
+					fillCircle(ret, cx, cy, img.discRadius()+1, imageBlack)
+				}
+				fillCircle(ret, cx, cy, img.discRadius(), fill)
+				if n := img.number(pos); n != "" {
+					if img.isLast(pos) {
+						ink = imageMarker
+					}
+					drawText(ret, n, cx, cy, scale, ink)
+					continue
+				}
+			case None:
+				if img.legal[pos] {
+					fillCircle(ret, cx, cy, img.dotRadius(), imageLegal)
+				}
+			}
+			if img.isLast(pos) {
+				fillCircle(ret, cx, cy, img.dotRadius(), imageMarker)
+			}
+		}
+	}
+	return ret
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	r = r.Intersect(img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+}
+
+// fillCircle draws a filled circle of radius r centred on the middle of pixel
+// (cx, cy), blending the edge pixels for a smooth outline.
+func fillCircle(img *image.RGBA, cx, cy int, r float64, c color.RGBA) {
+	n := int(math.Ceil(r)) + 1
+	for y := cy - n; y <= cy+n; y++ {
+		for x := cx - n; x <= cx+n; x++ {
+			if !(image.Point{X: x, Y: y}).In(img.Bounds()) {
+				continue
+			}
+			d := math.Hypot(float64(x-cx), float64(y-cy))
+			a := r + 0.5 - d
+			if a <= 0 {
+				continue
+			}
+			if a > 1 {
+				a = 1
+			}
+			img.SetRGBA(x, y, blend(img.RGBAAt(x, y), c, a))
+		}
+	}
+}
+
+func blend(dst, src color.RGBA, a float64) color.RGBA {
+	mix := func(d, s uint8) uint8 {
+		return uint8(math.Round(float64(d)*(1-a) + float64(s)*a))
+	}
+	return color.RGBA{mix(dst.R, src.R), mix(dst.G, src.G), mix(dst.B, src.B), 0xff}
+}
@@ -0,0 +1,91 @@
+package reversi
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestBoard_PNG(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		opts   []RenderOption
+		width  int
+		height int
+	}{
+		{desc: "when default", width: 8*40 + 2*24, height: 8*40 + 2*24},
+		{desc: "when no coordinates", opts: []RenderOption{WithCoordinates(false), WithCellSize(10)}, width: 80, height: 80},
+	}
+	for _, tc := range testcases {
+		var buf bytes.Buffer
+		if err := NewBoard(InitBoard).PNG(&buf, tc.opts...); err != nil {
+			t.Fatal(err)
+		}
+		img, err := png.Decode(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := img.Bounds().Size()
+		if size.X != tc.width || size.Y != tc.height {
+			t.Errorf("%s, got: %v, expected: %dx%d", tc.desc, size, tc.width, tc.height)
+		}
+	}
+}
+
+func TestGame_Image(t *testing.T) {
+	game, err := ParseTranscript("f5d6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := game.Image(WithCoordinates(false))
+	testcases := []struct {
+		desc     string
+		pos      Position
+		expected [3]uint8
+	}{
+		{desc: "when black disc", pos: Position{X: 4, Y: 3}, expected: [3]uint8{imageBlack.R, imageBlack.G, imageBlack.B}},
+		{desc: "when white disc", pos: Position{X: 3, Y: 4}, expected: [3]uint8{imageWhite.R, imageWhite.G, imageWhite.B}},
+		{desc: "when last move", pos: Position{X: 3, Y: 5}, expected: [3]uint8{imageMarker.R, imageMarker.G, imageMarker.B}},
+		{desc: "when legal move", pos: Position{X: 2, Y: 2}, expected: [3]uint8{imageLegal.R, imageLegal.G, imageLegal.B}},
+		{desc: "when empty", pos: Position{X: 0, Y: 0}, expected: [3]uint8{imageBoard.R, imageBoard.G, imageBoard.B}},
+	}
+	for _, tc := range testcases {
+		c := img.RGBAAt(tc.pos.X*40+20, tc.pos.Y*40+20)
+		actual := [3]uint8{c.R, c.G, c.B}
+		if actual != tc.expected {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, actual, tc.expected)
+		}
+	}
+}
+
+func TestGame_SVG(t *testing.T) {
+	game, err := ParseTranscript("f5d6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := game.SVG(&buf, WithMoveNumbers(true)); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	testcases := []struct {
+		desc     string
+		expected string
+		count    int
+	}{
+		{desc: "when discs", expected: `r="16.8"`, count: 6},
+		{desc: "when legal moves", expected: `r="4.0" fill="#1b4d31"`, count: 5},
+		{desc: "when last move number", expected: `fill="#e03c31">2</text>`, count: 1},
+		{desc: "when first move number", expected: `fill="#f5f5f5">1</text>`, count: 1},
+		{desc: "when coordinates", expected: `>h</text>`, count: 1},
+	}
+	if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("not an svg document: %s", svg)
+	}
+	for _, tc := range testcases {
+		if actual := strings.Count(svg, tc.expected); actual != tc.count {
+			t.Errorf("%s, got: %d, expected: %d", tc.desc, actual, tc.count)
+		}
+	}
+}
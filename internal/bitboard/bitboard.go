@@ -0,0 +1,117 @@
+// Package bitboard implements move generation on 8x8 boards held as 64-bit
+// masks, where bit y*8+x stands for the cell at (x, y).
+package bitboard
+
+import "math/bits"
+
+const (
+	Size = 8
+
+	notFileA uint64 = 0xfefefefefefefefe // every column except x == 0
+	notFileH uint64 = 0x7f7f7f7f7f7f7f7f // every column except x == 7
+)
+
+type direction struct {
+	shift int // positive shifts left, negative shifts right
+	mask  uint64
+}
+
+var directions = []direction{
+	{shift: -8, mask: ^uint64(0)}, // top
+	{shift: -7, mask: notFileA},   // top right
+	{shift: 1, mask: notFileA},    // right
+	{shift: 9, mask: notFileA},    // bottom right
+	{shift: 8, mask: ^uint64(0)},  // bottom
+	{shift: 7, mask: notFileH},    // bottom left
+	{shift: -1, mask: notFileH},   // left
+	{shift: -9, mask: notFileH},   // top left
+}
+
+func (d direction) apply(m uint64) uint64 {
+	if d.shift > 0 {
+		return (m << uint(d.shift)) & d.mask
+	}
+	return (m >> uint(-d.shift)) & d.mask
+}
+
+// Bit returns the mask of the cell at (x, y).
+func Bit(x, y int) uint64 {
+	return 1 << uint(y*Size+x)
+}
+
+// Moves returns the cells of empty where the player holding p can move
+// against the opponent holding o.
+func Moves(p, o, empty uint64) uint64 {
+	var ret uint64
+	for _, d := range directions {
+		x := d.apply(p) & o
+		x |= d.apply(x) & o
+		x |= d.apply(x) & o
+		x |= d.apply(x) & o
+		x |= d.apply(x) & o
+		x |= d.apply(x) & o
+		ret |= d.apply(x) & empty
+	}
+	return ret
+}
+
+// Flips returns the discs of o turned over when the player holding p moves
+// on the empty cell move.
+func Flips(p, o, move uint64) uint64 {
+	var ret uint64
+	for _, d := range directions {
+		var line uint64
+		next := d.apply(move)
+		for next&o != 0 {
+			line |= next
+			next = d.apply(next)
+		}
+		if next&p != 0 {
+			ret |= line
+		}
+	}
+	return ret
+}
+
+// Frontier returns the discs of p that have a neighbour in empty.
+func Frontier(p, empty uint64) uint64 {
+	var around uint64
+	for _, d := range directions {
+		around |= d.apply(empty)
+	}
+	return p & around
+}
+
+// FlipVertical maps the cell at (x, y) to (x, 7-y).
+func FlipVertical(m uint64) uint64 {
+	return bits.ReverseBytes64(m)
+}
+
+// MirrorHorizontal maps the cell at (x, y) to (7-x, y).
+func MirrorHorizontal(m uint64) uint64 {
+	const (
+		k1 = 0x5555555555555555
+		k2 = 0x3333333333333333
+		k4 = 0x0f0f0f0f0f0f0f0f
+	)
+	m = ((m >> 1) & k1) | ((m & k1) << 1)
+	m = ((m >> 2) & k2) | ((m & k2) << 2)
+	m = ((m >> 4) & k4) | ((m & k4) << 4)
+	return m
+}
+
+// Transpose maps the cell at (x, y) to (y, x).
+func Transpose(m uint64) uint64 {
+	const (
+		k1 = 0x5500550055005500
+		k2 = 0x3333000033330000
+		k4 = 0x0f0f0f0f00000000
+	)
+	t := k4 & (m ^ (m << 28))
+	m ^= t ^ (t >> 28)
+	t = k2 & (m ^ (m << 14))
+	m ^= t ^ (t >> 14)
+	t = k1 & (m ^ (m << 7))
+	m ^= t ^ (t >> 7)
+	return m
+}
@@ -0,0 +1,24 @@
+// Package zobrist provides the Zobrist keys used to hash positions.
+//
+// Keys are derived from the cell index with a SplitMix64 step rather than
+// read from a table, so boards of any size can be hashed.
+package zobrist
+
+// Turn is mixed into a hash when white is to move.
+const Turn uint64 = 0x9e3779b97f4a7c15
+
+// Key returns the key of a cell with the given index holding state, which is
+// 1 for black, 2 for white and 3 for a wall. Empty cells have no key.
+func Key(index, state int) uint64 {
+	if state == 0 {
+		return 0
+	}
+	return mix(uint64(index)*4 + uint64(state))
+}
+
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
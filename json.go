@@ -0,0 +1,157 @@
+package reversi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSONVersion is the version of the schema written by Game.MarshalJSON.
+const JSONVersion = 1
+
+// gameJSON is the JSON form of a game. Start, First, Rules, History and Ply
+// are enough to rebuild the game; the other fields describe the current
+// position for clients and are checked against the replay when decoding.
+type gameJSON struct {
+	Version   int         `json:"version"`
+	Rules     string      `json:"rules"`
+	Width     int         `json:"width"`
+	Height    int         `json:"height"`
+	Start     [][]int     `json:"start"`
+	First     Color       `json:"first"`
+	Board     [][]Cell    `json:"board"`
+	State     GameState   `json:"state"`
+	Turn      Color       `json:"turn"`
+	EndReason EndReason   `json:"end_reason"`
+	History   []*Move     `json:"history"`
+	Ply       int         `json:"ply"`
+	Legal     []*Position `json:"legal_moves"`
+	Scores    scoresJSON  `json:"scores"`
+	Winner    Color       `json:"winner"`
+}
+
+type scoresJSON struct {
+	Black int `json:"black"`
+	White int `json:"white"`
+}
+
+// MarshalJSON encodes the game, including the undone moves of its history,
+// so that UnmarshalJSON can restore it exactly. Winner is None until the game
+// is finished.
+func (game *Game) MarshalJSON() ([]byte, error) {
+	start := game.Clone()
+	start.logger = nil
+	start.board.logger = nil
+	start.JumpTo(0)
+
+	turn := game.GameState.Turn()
+	legal := []*Position{}
+	if turn.Valid() {
+		legal = game.ListAllocatablePositions(turn)
+	}
+	winner := None
+	if game.GameState == Finish {
+		winner = game.Winner()
+	}
+	history := game.history
+	if history == nil {
+		history = []*Move{}
+	}
+	return json.Marshal(&gameJSON{
+		Version:   JSONVersion,
+		Rules:     game.Rules().Name(),
+		Width:     game.board.Width,
+		Height:    game.board.Height,
+		Start:     start.board.toArray(),
+		First:     baseColor(game.first),
+		Board:     game.board.Snapshot(),
+		State:     game.GameState,
+		Turn:      turn,
+		EndReason: game.endReason,
+		History:   history,
+		Ply:       game.ply,
+		Legal:     legal,
+		Scores:    scoresJSON{Black: game.board.Count(Black), White: game.board.Count(White)},
+		Winner:    winner,
+	})
+}
+
+// UnmarshalJSON restores a game written by MarshalJSON by replaying its
+// history, which must consist of legal moves. Passes must appear exactly
+// where the game records them. Rules are rebuilt from their name unless the
+// game already has rules set, which is needed for custom Rules.
+func (game *Game) UnmarshalJSON(data []byte) error {
+	var g gameJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Version != JSONVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrJSON, g.Version)
+	}
+	rules := game.rules
+	if rules == nil {
+		r, err := rulesByName(g.Rules)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrJSON, err)
+		}
+		rules = r
+	}
+	if err := ValidateLayout(g.Start); err != nil {
+		return fmt.Errorf("%w: start: %v", ErrJSON, err)
+	}
+
+	board := NewBoard(g.Start)
+	board.SetLogger(game.logger)
+	ret := &Game{board: board, first: baseColor(g.First), rules: rules, logger: game.logger}
+	if g.State != Prepare {
+		ret.Start()
+	}
+	for i, m := range g.History {
+		if m == nil {
+			return fmt.Errorf("%w: history %d: null move", ErrJSON, i+1)
+		}
+		if i < ret.ply {
+			// Already recorded as a pass by the replay.
+			if !m.IsPass() || m.Color != ret.history[i].Color {
+				return fmt.Errorf("%w: history %d: expected a pass by %s", ErrJSON, i+1, ret.history[i].Color)
+			}
+			continue
+		}
+		if m.IsPass() {
+			return fmt.Errorf("%w: history %d: unexpected pass by %s", ErrJSON, i+1, m.Color)
+		}
+		if err := ret.SetStone(m.Color, m.Pos); err != nil {
+			return fmt.Errorf("%w: history %d (%s): %v", ErrJSON, i+1, m.Pos, err)
+		}
+	}
+	if ret.ply != len(g.History) {
+		return fmt.Errorf("%w: history ends before a pass by %s", ErrJSON, ret.history[len(ret.history)-1].Color)
+	}
+	if err := ret.JumpTo(g.Ply); err != nil {
+		return fmt.Errorf("%w: %v", ErrJSON, err)
+	}
+	if ret.ply != g.Ply || ret.GameState != g.State {
+		return fmt.Errorf("%w: ply %d in state %s does not match the history", ErrJSON, g.Ply, g.State)
+	}
+	if g.Board != nil && !boardMatches(ret.board, g.Board) {
+		return fmt.Errorf("%w: board does not match the history", ErrJSON)
+	}
+	*game = *ret
+	return nil
+}
+
+func boardMatches(b *Board, cells [][]Cell) bool {
+	if len(cells) != b.Height {
+		return false
+	}
+	for i, line := range cells {
+		if len(line) != b.Width {
+			return false
+		}
+		for j, c := range line {
+			if b.Cell(j, i).State != c.State {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package reversi
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGame_MarshalJSON(t *testing.T) {
+	game, err := ParseTranscript("f5d6c3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g gameJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		t.Fatal(err)
+	}
+	if g.Version != JSONVersion || g.Rules != "standard" || g.State != WhiteTurn || g.Turn != White {
+		t.Errorf("unexpected header: %s", data)
+	}
+	if g.Scores.Black != game.board.Count(Black) || g.Scores.White != game.board.Count(White) {
+		t.Errorf("scores got: %+v", g.Scores)
+	}
+	if len(g.Legal) != len(game.ListAllocatablePositions(White)) {
+		t.Errorf("legal moves got: %d, expected: %d", len(g.Legal), len(game.ListAllocatablePositions(White)))
+	}
+	if !matchArray(g.Start, InitBoard) {
+		t.Errorf("start is not the initial board")
+	}
+	if len(g.History) != 3 || *g.History[0].Pos != (Position{X: 5, Y: 4}) {
+		t.Errorf("history got: %d moves", len(g.History))
+	}
+}
+
+func TestGame_UnmarshalJSON(t *testing.T) {
+	undone, _ := ParseTranscript("f5d6c3d3c4")
+	undone.Undo()
+	undone.Undo()
+
+	anti, _ := NewGame(WithRules(AntiReversi{Base: Octagon{}}))
+	anti.Start()
+	anti.SetStone(Black, &Position{X: 5, Y: 4})
+
+	finished, _ := NewGame(WithSize(4))
+	finished.Start()
+	for finished.GameState != Finish {
+		turn := finished.GameState.Turn()
+		finished.SetStone(turn, finished.ListAllocatablePositions(turn)[0])
+	}
+	pass, _ := NewGame(WithLayout([][]int{
+		{0, 2, 1, 1},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+	}), WithFirstPlayer(White))
+	pass.Start()
+	prepare, _ := NewGame()
+
+	testcases := []struct {
+		desc string
+		game *Game
+	}{
+		{desc: "when moves are undone", game: undone},
+		{desc: "when rules are a variant", game: anti},
+		{desc: "when the game is finished", game: finished},
+		{desc: "when the first player passes", game: pass},
+		{desc: "when the game is not started", game: prepare},
+	}
+	for _, tc := range testcases {
+		data, err := json.Marshal(tc.game)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual Game
+		if err := json.Unmarshal(data, &actual); err != nil {
+			t.Fatalf("%s, %v", tc.desc, err)
+		}
+		if !actual.board.Equal(tc.game.board) || actual.GameState != tc.game.GameState || actual.Ply() != tc.game.Ply() ||
+			len(actual.history) != len(tc.game.history) || actual.EndReason() != tc.game.EndReason() ||
+			actual.Rules().Name() != tc.game.Rules().Name() {
+			t.Errorf("%s, got: %s, expected: %s", tc.desc, actual.GameState, tc.game.GameState)
+		}
+		again, _ := json.Marshal(&actual)
+		if string(again) != string(data) {
+			t.Errorf("%s, got: %s, expected: %s", tc.desc, again, data)
+		}
+	}
+	if !undone.Redo() {
+		t.Fatal("nothing to redo")
+	}
+}
+
+func TestGame_UnmarshalJSON_errors(t *testing.T) {
+	game, _ := ParseTranscript("f5d6")
+	data, _ := json.Marshal(game)
+	valid := string(data)
+
+	testcases := []struct {
+		desc string
+		data string
+	}{
+		{desc: "when version is unknown", data: strings.Replace(valid, `"version":1`, `"version":2`, 1)},
+		{desc: "when rules are unknown", data: strings.Replace(valid, `"rules":"standard"`, `"rules":"chess"`, 1)},
+		{desc: "when a move is illegal", data: strings.Replace(valid, `"pos":{"x":3,"y":5}`, `"pos":{"x":0,"y":0}`, 1)},
+		{desc: "when the ply is out of range", data: strings.Replace(valid, `"ply":2`, `"ply":5`, 1)},
+		{desc: "when the state does not match", data: strings.Replace(valid, `"state":1`, `"state":2`, 1)},
+		{desc: "when the board does not match", data: strings.Replace(valid, `"x":0,"y":0,"state":0`, `"x":0,"y":0,"state":1`, 1)},
+	}
+	for _, tc := range testcases {
+		if tc.data == valid {
+			t.Fatalf("%s, replacement did not apply", tc.desc)
+		}
+		var actual Game
+		if err := json.Unmarshal([]byte(tc.data), &actual); !errors.Is(err, ErrJSON) {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, err, ErrJSON)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package reversi
+
+import (
+	"log/slog"
+)
+
+// SetLogger makes the board report moves and rejected moves to l. A nil
+// logger, the default, keeps the board silent.
+func (b *Board) SetLogger(l *slog.Logger) {
+	b.logger = l
+}
+
+// SetLogger makes the game and its board report moves, passes, phase
+// transitions and rejected moves to l. A nil logger, the default, keeps the
+// game silent.
+func (game *Game) SetLogger(l *slog.Logger) {
+	game.logger = l
+	game.board.SetLogger(l)
+}
+
+func logMove(l *slog.Logger, color Color, pos *Position, flipped []*Position) {
+	if l == nil {
+		return
+	}
+	l.Debug("move", "color", color.String(), "x", pos.X, "y", pos.Y, "flips", len(flipped))
+}
+
+func logReject(l *slog.Logger, color Color, pos *Position, err error) {
+	if l == nil {
+		return
+	}
+	l.Info("move rejected", "color", color.String(), "x", pos.X, "y", pos.Y, "error", err)
+}
+
+func logPass(l *slog.Logger, color Color) {
+	if l == nil {
+		return
+	}
+	l.Debug("pass", "color", color.String())
+}
+
+func logPhase(l *slog.Logger, from, to GameState, reason EndReason) {
+	if l == nil {
+		return
+	}
+	if to == Finish {
+		l.Debug("phase", "from", from.String(), "to", to.String(), "reason", reason.String())
+		return
+	}
+	l.Debug("phase", "from", from.String(), "to", to.String())
+}
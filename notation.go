@@ -0,0 +1,134 @@
+package reversi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the position in algebraic notation: a column letter
+// followed by a 1-based row number, like "f5". Columns past "z" continue with
+// "aa", "ab" and so on.
+func (p Position) String() string {
+	return columnName(p.X) + strconv.Itoa(p.Y+1)
+}
+
+// ParsePosition parses a position in algebraic notation. Letters are case
+// insensitive.
+func ParsePosition(s string) (*Position, error) {
+	pos, n, err := parsePosition(strings.ToLower(s))
+	if err != nil {
+		return nil, err
+	}
+	if n != len(s) {
+		return nil, fmt.Errorf("%w: %q", ErrNotation, s)
+	}
+	return pos, nil
+}
+
+// ParseTranscript starts a new game and plays the moves of a transcript such
+// as "f5d6c3d3c4" through it. See Game.PlayTranscript.
+func ParseTranscript(transcript string, opts ...Option) (*Game, error) {
+	game, err := NewGame(opts...)
+	if err != nil {
+		return nil, err
+	}
+	game.Start()
+	if err := game.PlayTranscript(transcript); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// PlayTranscript plays the moves of a transcript for whichever side is to
+// move. Moves may be separated by spaces or commas. Passes are inserted by
+// the game when needed, so the transcript may omit them; an explicit "pass"
+// (or "pa", "ps", "--") is accepted only where a pass happened.
+func (game *Game) PlayTranscript(transcript string) error {
+	s := strings.ToLower(transcript)
+	passed := false
+	for i, n := 0, 0; ; n++ {
+		for i < len(s) && strings.IndexByte(" \t\r\n,;", s[i]) >= 0 {
+			i++
+		}
+		if i == len(s) {
+			return nil
+		}
+		if l := passToken(s[i:]); l > 0 {
+			if passed || game.ply == 0 || !game.history[game.ply-1].IsPass() {
+				return fmt.Errorf("move %d: %w: unexpected pass", n+1, ErrNotation)
+			}
+			passed = true
+			i += l
+			continue
+		}
+		pos, l, err := parsePosition(s[i:])
+		if err != nil {
+			return fmt.Errorf("move %d: %w", n+1, err)
+		}
+		if err := game.SetStone(game.GameState.Turn(), pos); err != nil {
+			return fmt.Errorf("move %d (%s): %w", n+1, pos, err)
+		}
+		passed = false
+		i += l
+	}
+}
+
+// Transcript returns the moves played so far in algebraic notation, without
+// passes, e.g. "f5d6c3d3c4".
+func (game *Game) Transcript() string {
+	var sb strings.Builder
+	for _, m := range game.History() {
+		if !m.IsPass() {
+			sb.WriteString(m.Pos.String())
+		}
+	}
+	return sb.String()
+}
+
+func columnName(x int) string {
+	if x < 0 {
+		return "?"
+	}
+	name := ""
+	for x++; x > 0; x = (x - 1) / 26 {
+		name = string(rune('a'+(x-1)%26)) + name
+	}
+	return name
+}
+
+// parsePosition reads a lower case position at the start of s and returns
+// it with the number of bytes consumed.
+func parsePosition(s string) (*Position, int, error) {
+	i, x := 0, 0
+	for i < len(s) && s[i] >= 'a' && s[i] <= 'z' && x <= 1<<16 {
+		x = x*26 + int(s[i]-'a') + 1
+		i++
+	}
+	j := i
+	for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+		j++
+	}
+	if i == 0 || j == i || x > 1<<16 {
+		return nil, 0, fmt.Errorf("%w: %q", ErrNotation, s)
+	}
+	y, err := strconv.Atoi(s[i:j])
+	if err != nil || y < 1 {
+		return nil, 0, fmt.Errorf("%w: %q", ErrNotation, s[:j])
+	}
+	return &Position{X: x - 1, Y: y - 1}, j, nil
+}
+
+// passToken returns the length of a pass token at the start of s, or 0.
+func passToken(s string) int {
+	for _, tok := range []string{"pass", "pa", "ps", "--"} {
+		if !strings.HasPrefix(s, tok) {
+			continue
+		}
+		if len(s) > len(tok) && s[len(tok)] >= '0' && s[len(tok)] <= '9' {
+			return 0
+		}
+		return len(tok)
+	}
+	return 0
+}
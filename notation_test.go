@@ -0,0 +1,166 @@
+package reversi
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestPosition_String(t *testing.T) {
+	testcases := []struct {
+		pos      Position
+		expected string
+	}{
+		{pos: Position{X: 0, Y: 0}, expected: "a1"},
+		{pos: Position{X: 5, Y: 4}, expected: "f5"},
+		{pos: Position{X: 7, Y: 7}, expected: "h8"},
+		{pos: Position{X: 9, Y: 9}, expected: "j10"},
+		{pos: Position{X: 25, Y: 0}, expected: "z1"},
+		{pos: Position{X: 26, Y: 0}, expected: "aa1"},
+		{pos: Position{X: 27, Y: 11}, expected: "ab12"},
+	}
+	for _, tc := range testcases {
+		if actual := tc.pos.String(); actual != tc.expected {
+			t.Errorf("got: %s, expected: %s", actual, tc.expected)
+		}
+		pos, err := ParsePosition(tc.expected)
+		if err != nil {
+			t.Errorf("%s, unexpected error: %v", tc.expected, err)
+		} else if *pos != tc.pos {
+			t.Errorf("%s, got: (%d, %d), expected: (%d, %d)", tc.expected, pos.X, pos.Y, tc.pos.X, tc.pos.Y)
+		}
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	testcases := []struct {
+		input    string
+		expected *Position
+	}{
+		{input: "F5", expected: &Position{X: 5, Y: 4}},
+		{input: "c10", expected: &Position{X: 2, Y: 9}},
+		{input: "", expected: nil},
+		{input: "f", expected: nil},
+		{input: "5", expected: nil},
+		{input: "f0", expected: nil},
+		{input: "f5d6", expected: nil},
+		{input: "f-5", expected: nil},
+		{input: "zzzzzzzzzzzzzzzzzzzz1", expected: nil},
+	}
+	for _, tc := range testcases {
+		actual, err := ParsePosition(tc.input)
+		if tc.expected == nil {
+			if !errors.Is(err, ErrNotation) {
+				t.Errorf("%q, got: %v, %v, expected: ErrNotation", tc.input, actual, err)
+			}
+			continue
+		}
+		if err != nil || *actual != *tc.expected {
+			t.Errorf("%q, got: %v, %v, expected: %v", tc.input, actual, err, tc.expected)
+		}
+	}
+}
+
+func TestParseTranscript(t *testing.T) {
+	passLayout := WithLayout([][]int{
+		{0, 2, 1, 0},
+		{0, 0, 0, 0},
+		{0, 0, 2, 1},
+		{0, 0, 0, 0},
+	})
+	testcases := []struct {
+		desc       string
+		transcript string
+		opts       []Option
+		expected   string
+		ply        int
+		err        error
+	}{
+		{
+			desc:       "when tiger opening",
+			transcript: "f5d6c3d3c4",
+			expected:   "f5d6c3d3c4",
+			ply:        5,
+		},
+		{
+			desc:       "when separated and upper case",
+			transcript: "F5 d6, C3\nd3 c4",
+			expected:   "f5d6c3d3c4",
+			ply:        5,
+		},
+		{
+			desc:       "when pass is implicit",
+			transcript: "a1b3",
+			opts:       []Option{passLayout},
+			expected:   "a1b3",
+			ply:        3,
+		},
+		{
+			desc:       "when pass is explicit",
+			transcript: "a1 pass b3",
+			opts:       []Option{passLayout},
+			expected:   "a1b3",
+			ply:        3,
+		},
+		{
+			desc:       "when pass is misplaced",
+			transcript: "f5 pass d6",
+			err:        ErrNotation,
+		},
+		{
+			desc:       "when move is illegal",
+			transcript: "f5f5",
+			err:        ErrOccupied,
+		},
+		{
+			desc:       "when notation is broken",
+			transcript: "f5d",
+			err:        ErrNotation,
+		},
+	}
+
+	for _, tc := range testcases {
+		game, err := ParseTranscript(tc.transcript, tc.opts...)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%s, got: %v, expected: %v", tc.desc, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s, unexpected error: %v", tc.desc, err)
+			continue
+		}
+		if actual := game.Transcript(); actual != tc.expected {
+			t.Errorf("%s, got: %s, expected: %s", tc.desc, actual, tc.expected)
+		}
+		if game.Ply() != tc.ply {
+			t.Errorf("%s, ply got: %d, expected: %d", tc.desc, game.Ply(), tc.ply)
+		}
+	}
+}
+
+func TestGame_Transcript(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, size := range []int{6, 8, 10} {
+		game, err := NewGame(WithSize(size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.Start()
+		for game.GameState != Finish {
+			moves := game.ListAllocatablePositions(game.GameState.Turn())
+			if err := game.SetStone(game.GameState.Turn(), moves[rnd.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		replayed, err := ParseTranscript(game.Transcript(), WithSize(size))
+		if err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		if !replayed.board.Equal(game.board) || replayed.GameState != Finish || replayed.Ply() != game.Ply() {
+			t.Errorf("size %d: replayed game differs", size)
+		}
+	}
+}
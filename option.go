@@ -0,0 +1,119 @@
+package reversi
+
+import (
+	"fmt"
+)
+
+// Option configures a game created by NewGame.
+type Option func(*gameConfig)
+
+type gameConfig struct {
+	size   int
+	layout [][]int
+	first  Color
+	rules  Rules
+}
+
+// WithSize plays on an empty size x size board with the four starting discs
+// in the centre. size must be even and at least 4.
+func WithSize(size int) Option {
+	return func(c *gameConfig) {
+		c.size = size
+	}
+}
+
+// WithLayout starts the game from a custom position. It takes precedence
+// over WithSize.
+func WithLayout(layout [][]int) Option {
+	return func(c *gameConfig) {
+		c.layout = layout
+	}
+}
+
+// WithFirstPlayer sets the color that moves first. Black moves first by
+// default.
+func WithFirstPlayer(color Color) Option {
+	return func(c *gameConfig) {
+		c.first = color
+	}
+}
+
+// WithRules plays the game under a rule variant instead of Standard.
+func WithRules(rules Rules) Option {
+	return func(c *gameConfig) {
+		c.rules = rules
+	}
+}
+
+// DefaultLayout returns the starting position for a size x size board,
+// oriented like InitBoard.
+func DefaultLayout(size int) [][]int {
+	ret := make([][]int, size)
+	for i := range ret {
+		ret[i] = make([]int, size)
+	}
+	if size < 2 {
+		return ret
+	}
+	c := size / 2
+	ret[c-1][c-1] = int(White)
+	ret[c-1][c] = int(Black)
+	ret[c][c-1] = int(Black)
+	ret[c][c] = int(White)
+	return ret
+}
+
+// ValidateLayout checks that layout is a non-empty rectangle holding only
+// known cell states.
+func ValidateLayout(layout [][]int) error {
+	if len(layout) == 0 || len(layout[0]) == 0 {
+		return &LayoutError{Row: -1, Col: -1, Reason: "empty layout"}
+	}
+	width := len(layout[0])
+	for i, line := range layout {
+		if len(line) != width {
+			return &LayoutError{Row: i, Col: -1, Reason: fmt.Sprintf("row has %d cells, expected %d", len(line), width)}
+		}
+		for j, state := range line {
+			if state < int(None) || state > int(Wall) {
+				return &LayoutError{Row: i, Col: j, Reason: fmt.Sprintf("unknown cell state %d", state)}
+			}
+		}
+	}
+	return nil
+}
+
+func validateSize(size int) error {
+	if size < 4 || size%2 != 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidSize, size)
+	}
+	return nil
+}
+
+func newGameConfig(opts []Option) (*gameConfig, error) {
+	c := &gameConfig{size: len(InitBoard), first: Black}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if err := validateColor(c.first); err != nil {
+		return nil, err
+	}
+	if c.layout == nil {
+		if err := validateSize(c.size); err != nil {
+			return nil, err
+		}
+		c.layout = DefaultLayout(c.size)
+	}
+	if err := ValidateLayout(c.layout); err != nil {
+		return nil, err
+	}
+	layout, err := baseRules(c.rules).Setup(c.layout)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateLayout(layout); err != nil {
+		return nil, err
+	}
+	c.layout = layout
+	return c, nil
+}
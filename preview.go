@@ -0,0 +1,93 @@
+package reversi
+
+import (
+	"math/bits"
+)
+
+// Candidate is a legal move together with the number of discs it flips.
+type Candidate struct {
+	Position
+	Flips int
+}
+
+// Flips returns the discs that color would flip by playing at pos, without
+// changing the board. It returns an empty list when the move is illegal.
+func (b *Board) Flips(color Color, pos *Position) []*Position {
+	flipped, err := b.check(color, pos)
+	if err != nil {
+		return []*Position{}
+	}
+	return flipped
+}
+
+// Apply returns a new board with the move played, leaving b unchanged.
+func (b *Board) Apply(color Color, pos *Position) (*Board, error) {
+	flipped, err := b.check(color, pos)
+	if err != nil {
+		return nil, err
+	}
+	ret := b.Clone()
+	ret.replay(color, pos, flipped)
+	return ret, nil
+}
+
+// ListCandidates works like ListAllocatablePositions and also reports how
+// many discs each move flips.
+func (b *Board) ListCandidates(color Color) []*Candidate {
+	positions := b.ListAllocatablePositions(color)
+	ret := make([]*Candidate, 0, len(positions))
+	for _, pos := range positions {
+		cell := b.Cell(pos.X, pos.Y)
+		ret = append(ret, &Candidate{Position: *pos, Flips: len(b.flipped(color, cell))})
+	}
+	return ret
+}
+
+// Mobility returns the number of legal moves for color.
+func (b *Board) Mobility(color Color) int {
+	if !color.Valid() {
+		return 0
+	}
+	if b.bits != nil {
+		return bits.OnesCount64(b.bits.moves(color))
+	}
+	return len(b.ListAllocatablePositions(color))
+}
+
+// Frontier returns the number of discs of color that touch an empty cell.
+func (b *Board) Frontier(color Color) int {
+	if !color.Valid() {
+		return 0
+	}
+	if b.bits != nil {
+		return bits.OnesCount64(b.bits.frontier(color))
+	}
+	ret := 0
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			cell := b.Cell(j, i)
+			if cell.State == color && b.touchesEmpty(cell) {
+				ret++
+			}
+		}
+	}
+	return ret
+}
+
+func (b *Board) touchesEmpty(cell *Cell) bool {
+	for _, d := range directions {
+		if next, _ := b.next(d, cell); next != nil && next.State == None {
+			return true
+		}
+	}
+	return false
+}
+
+// Flips returns the discs that color would flip by playing at pos.
+func (game *Game) Flips(color Color, pos *Position) []*Position {
+	return game.board.Flips(color, pos)
+}
+
+func (game *Game) ListCandidates(color Color) []*Candidate {
+	return game.board.ListCandidates(color)
+}
@@ -0,0 +1,220 @@
+package reversi
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Style selects the characters used by Render.
+type Style int
+
+const (
+	StyleASCII   Style = iota // X for black, O for white, . for empty
+	StyleUnicode              // ● for black, ○ for white, · for empty
+	StyleANSI                 // Unicode discs on a green board using ANSI colours
+)
+
+func (s Style) String() string {
+	switch s {
+	case StyleASCII:
+		return "ascii"
+	case StyleUnicode:
+		return "unicode"
+	case StyleANSI:
+		return "ansi"
+	default:
+		return fmt.Sprintf("Style(%d)", int(s))
+	}
+}
+
+// ParseStyle returns the style named by s, as returned by Style.String.
+func ParseStyle(s string) (Style, error) {
+	for _, style := range []Style{StyleASCII, StyleUnicode, StyleANSI} {
+		if strings.EqualFold(s, style.String()) {
+			return style, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown style %q", s)
+}
+
+// RenderOption configures Board.Render and Game.Render.
+type RenderOption func(*renderConfig)
+
+type renderConfig struct {
+	style       Style
+	coordinates bool
+	legal       Color
+	moves       []*Position // legal moves worked out by the caller
+	last        *Position
+	cellSize    int
+	moveNumbers bool
+	numbers     map[Position]int
+	flipped     []*Position
+	caption     string
+	delay       time.Duration
+}
+
+// WithStyle renders with style instead of StyleASCII.
+func WithStyle(style Style) RenderOption {
+	return func(c *renderConfig) {
+		c.style = style
+	}
+}
+
+// WithCoordinates turns the column letters and row numbers around the board
+// on or off. They are on by default.
+func WithCoordinates(on bool) RenderOption {
+	return func(c *renderConfig) {
+		c.coordinates = on
+	}
+}
+
+// WithLegalMoves marks the empty cells where color can move. None turns the
+// marks off.
+func WithLegalMoves(color Color) RenderOption {
+	return func(c *renderConfig) {
+		c.legal = color
+		c.moves = nil
+	}
+}
+
+// withMoves marks moves as the legal moves, for callers that know better
+// than the board which moves are allowed.
+func withMoves(moves []*Position) RenderOption {
+	return func(c *renderConfig) {
+		c.moves = moves
+	}
+}
+
+// WithLastMove brackets the disc on pos. nil turns the mark off.
+func WithLastMove(pos *Position) RenderOption {
+	return func(c *renderConfig) {
+		c.last = pos
+	}
+}
+
+var styleGlyphs = map[Style]map[Color]string{
+	StyleASCII:   {None: ".", Black: "X", White: "O", Wall: "#"},
+	StyleUnicode: {None: "·", Black: "●", White: "○", Wall: "■"},
+	StyleANSI:    {None: " ", Black: "●", White: "●", Wall: " "},
+}
+
+var legalGlyphs = map[Style]string{
+	StyleASCII:   "*",
+	StyleUnicode: "◦",
+	StyleANSI:    "·",
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBoard  = "\x1b[42m"
+	ansiWall   = "\x1b[100m"
+	ansiBlack  = "\x1b[30m"
+	ansiWhite  = "\x1b[97m"
+	ansiLegal  = "\x1b[33m"
+	ansiMarker = "\x1b[31m"
+)
+
+// Render writes the board to w, one row per line. Unknown cell states are
+// shown as "?".
+func (b *Board) Render(w io.Writer, opts ...RenderOption) error {
+	c := &renderConfig{coordinates: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+	moves := c.moves
+	if moves == nil {
+		moves = b.ListAllocatablePositions(c.legal)
+	}
+	legal := map[Position]bool{}
+	for _, pos := range moves {
+		legal[*pos] = true
+	}
+
+	var sb strings.Builder
+	label := len(fmt.Sprint(b.Height))
+	if c.coordinates {
+		header := strings.Repeat(" ", label+1)
+		for j := 0; j < b.Width; j++ {
+			header += fmt.Sprintf(" %-2s", columnName(j))
+		}
+		sb.WriteString(strings.TrimRight(header, " "))
+		sb.WriteString("\n")
+	}
+	for i := 0; i < b.Height; i++ {
+		var line strings.Builder
+		if c.coordinates {
+			fmt.Fprintf(&line, "%*d ", label, i+1)
+		}
+		for j := 0; j < b.Width; j++ {
+			pos := Position{X: j, Y: i}
+			last := c.last != nil && *c.last == pos
+			line.WriteString(c.cell(b.Cell(j, i).State, legal[pos], last))
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// cell renders one cell as three columns: the glyph between two spaces, or
+// between brackets for the last move.
+func (c *renderConfig) cell(state Color, legal, last bool) string {
+	glyph, ok := styleGlyphs[c.style][state]
+	if !ok {
+		glyph = "?"
+	}
+	if legal && state == None {
+		glyph = legalGlyphs[c.style]
+	}
+	left, right := " ", " "
+	if last {
+		left, right = "(", ")"
+	}
+	if c.style != StyleANSI {
+		return left + glyph + right
+	}
+
+	bg := ansiBoard
+	if state == Wall {
+		bg = ansiWall
+	}
+	fg := ""
+	switch {
+	case state == Black:
+		fg = ansiBlack
+	case state == White:
+		fg = ansiWhite
+	case legal:
+		fg = ansiLegal
+	}
+	return bg + ansiMarker + left + ansiReset + bg + fg + glyph + ansiReset + bg + ansiMarker + right + ansiReset
+}
+
+// Render writes the board to w, marking the moves the side to move may play
+// under the game rules and the last move played. Options given to Render
+// override those marks.
+func (game *Game) Render(w io.Writer, opts ...RenderOption) error {
+	return game.board.Render(w, append(game.renderOptions(), opts...)...)
+}
+
+// renderOptions returns the marks that depend on the game: its legal moves,
+// the last move and the number of each move.
+func (game *Game) renderOptions() []RenderOption {
+	var last *Position
+	numbers := map[Position]int{}
+	n := 0
+	for _, m := range game.history[:game.ply] {
+		if m.IsPass() {
+			continue
+		}
+		n++
+		numbers[*m.Pos] = n
+		last = m.Pos
+	}
+	moves := game.ListAllocatablePositions(game.GameState.Turn())
+	return []RenderOption{withMoves(moves), WithLastMove(last), withNumbers(numbers)}
+}
@@ -0,0 +1,81 @@
+package reversi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBoard_Render(t *testing.T) {
+	layout := [][]int{
+		{3, 0, 0, 0},
+		{0, 2, 1, 0},
+		{0, 1, 2, 0},
+		{0, 0, 0, 5},
+	}
+	testcases := []struct {
+		desc     string
+		opts     []RenderOption
+		expected string
+	}{
+		{
+			desc: "when default",
+			expected: "   a  b  c  d\n" +
+				"1  #  .  .  .\n" +
+				"2  .  O  X  .\n" +
+				"3  .  X  O  .\n" +
+				"4  .  .  .  ?\n",
+		},
+		{
+			desc: "when no coordinates with marks",
+			opts: []RenderOption{WithCoordinates(false), WithLegalMoves(Black), WithLastMove(&Position{X: 2, Y: 1})},
+			expected: " #  *  .  .\n" +
+				" *  O (X) .\n" +
+				" .  X  O  *\n" +
+				" .  .  *  ?\n",
+		},
+		{
+			desc:     "when unicode",
+			opts:     []RenderOption{WithStyle(StyleUnicode), WithCoordinates(false)},
+			expected: " ■  ·  ·  ·\n ·  ○  ●  ·\n ·  ●  ○  ·\n ·  ·  ·  ?\n",
+		},
+	}
+	for _, tc := range testcases {
+		var buf bytes.Buffer
+		if err := newGridBoard(layout).Render(&buf, tc.opts...); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("%s, got:\n%s\nexpected:\n%s", tc.desc, buf.String(), tc.expected)
+		}
+	}
+}
+
+func TestBoard_Render_ansi(t *testing.T) {
+	var buf bytes.Buffer
+	NewBoard(InitBoard).Render(&buf, WithStyle(StyleANSI))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 9 || !strings.Contains(lines[4], ansiBoard+ansiWhite+"●") || !strings.HasSuffix(lines[4], ansiReset) {
+		t.Errorf("got: %q", buf.String())
+	}
+}
+
+func TestGame_Render(t *testing.T) {
+	game, err := ParseTranscript("f5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	game.Render(&buf, WithCoordinates(false))
+	expected := " .  .  .  .  .  .  .  .\n" +
+		" .  .  .  .  .  .  .  .\n" +
+		" .  .  .  .  .  .  .  .\n" +
+		" .  .  .  O  X  *  .  .\n" +
+		" .  .  .  X  X (X) .  .\n" +
+		" .  .  .  *  .  *  .  .\n" +
+		" .  .  .  .  .  .  .  .\n" +
+		" .  .  .  .  .  .  .  .\n"
+	if buf.String() != expected {
+		t.Errorf("got:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
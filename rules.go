@@ -0,0 +1,237 @@
+package reversi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rules decides the parts of a game that differ between variants: the
+// starting position, which moves are allowed, when the game is over and who
+// wins. Standard is used when a game has no rules set.
+type Rules interface {
+	Name() string
+	// Setup returns the starting layout for the variant. It must not modify
+	// layout in place.
+	Setup(layout [][]int) ([][]int, error)
+	// Legal can forbid a move the board would otherwise accept. It is
+	// consulted before the board's own checks.
+	Legal(b *Board, color Color, pos *Position) error
+	// Finished reports why the position on b ends the game, or NotFinished.
+	// Games also finish when neither side has a legal move.
+	Finished(b *Board) EndReason
+	Winner(b *Board) Color
+}
+
+// Standard implements the usual Othello rules.
+type Standard struct{}
+
+func (Standard) Name() string {
+	return "standard"
+}
+
+func (Standard) Setup(layout [][]int) ([][]int, error) {
+	return layout, nil
+}
+
+func (Standard) Legal(b *Board, color Color, pos *Position) error {
+	return nil
+}
+
+func (Standard) Finished(b *Board) EndReason {
+	if b.IsOccupied() {
+		return BoardFull
+	}
+	if b.Count(Black) == 0 || b.Count(White) == 0 {
+		return Wipeout
+	}
+	return NotFinished
+}
+
+// Winner returns the color with the most discs, or None on a draw.
+func (Standard) Winner(b *Board) Color {
+	bCount := b.Count(Black)
+	wCount := b.Count(White)
+	if bCount > wCount {
+		return Black
+	} else if bCount < wCount {
+		return White
+	} else {
+		return None
+	}
+}
+
+// AntiReversi is the misère variant: the player with the fewest discs wins.
+// Everything else follows Base, or Standard when Base is nil.
+type AntiReversi struct {
+	Base Rules
+}
+
+func (r AntiReversi) Name() string {
+	return variantName("anti", r.Base)
+}
+
+func (r AntiReversi) Setup(layout [][]int) ([][]int, error) {
+	return baseRules(r.Base).Setup(layout)
+}
+
+func (r AntiReversi) Legal(b *Board, color Color, pos *Position) error {
+	return baseRules(r.Base).Legal(b, color, pos)
+}
+
+func (r AntiReversi) Finished(b *Board) EndReason {
+	return baseRules(r.Base).Finished(b)
+}
+
+func (r AntiReversi) Winner(b *Board) Color {
+	return baseRules(r.Base).Winner(b).Opponent()
+}
+
+// Blocked turns Squares into walls at the start of the game. Everything else
+// follows Base, or Standard when Base is nil.
+type Blocked struct {
+	Squares []Position
+	Base    Rules
+}
+
+func (r Blocked) Name() string {
+	return variantName("blocked", r.Base)
+}
+
+func (r Blocked) Setup(layout [][]int) ([][]int, error) {
+	layout, err := baseRules(r.Base).Setup(layout)
+	if err != nil {
+		return nil, err
+	}
+	ret := copyLayout(layout)
+	for _, sq := range r.Squares {
+		if err := placeWall(ret, sq.X, sq.Y); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func (r Blocked) Legal(b *Board, color Color, pos *Position) error {
+	return baseRules(r.Base).Legal(b, color, pos)
+}
+
+func (r Blocked) Finished(b *Board) EndReason {
+	return baseRules(r.Base).Finished(b)
+}
+
+func (r Blocked) Winner(b *Board) Color {
+	return baseRules(r.Base).Winner(b)
+}
+
+// Octagon cuts a triangle of Cut cells along each edge off every corner of
+// the board, turning them into walls. A Cut of 0 means 2, which removes three
+// cells per corner. Everything else follows Base, or Standard when Base is
+// nil.
+type Octagon struct {
+	Cut  int
+	Base Rules
+}
+
+func (r Octagon) Name() string {
+	return variantName("octagon", r.Base)
+}
+
+func (r Octagon) Setup(layout [][]int) ([][]int, error) {
+	layout, err := baseRules(r.Base).Setup(layout)
+	if err != nil {
+		return nil, err
+	}
+	cut := r.Cut
+	if cut <= 0 {
+		cut = 2
+	}
+	ret := copyLayout(layout)
+	for y, line := range ret {
+		for x := range line {
+			top, left := y, x
+			bottom, right := len(ret)-1-y, len(line)-1-x
+			if left+top < cut || right+top < cut || left+bottom < cut || right+bottom < cut {
+				if err := placeWall(ret, x, y); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return ret, nil
+}
+
+func (r Octagon) Legal(b *Board, color Color, pos *Position) error {
+	return baseRules(r.Base).Legal(b, color, pos)
+}
+
+func (r Octagon) Finished(b *Board) EndReason {
+	return baseRules(r.Base).Finished(b)
+}
+
+func (r Octagon) Winner(b *Board) Color {
+	return baseRules(r.Base).Winner(b)
+}
+
+// rulesByName rebuilds the rules named by Name, such as "anti+octagon".
+// Parameters that only affect Setup, like the squares of Blocked, are not
+// part of the name and are left at their zero values.
+func rulesByName(name string) (Rules, error) {
+	parts := strings.Split(name, "+")
+	var ret Rules
+	for i := len(parts) - 1; i >= 0; i-- {
+		switch parts[i] {
+		case "standard":
+			if len(parts) > 1 {
+				return nil, fmt.Errorf("unknown rules %q", name)
+			}
+			ret = Standard{}
+		case "anti":
+			ret = AntiReversi{Base: ret}
+		case "blocked":
+			ret = Blocked{Base: ret}
+		case "octagon":
+			ret = Octagon{Base: ret}
+		default:
+			return nil, fmt.Errorf("unknown rules %q", name)
+		}
+	}
+	return ret, nil
+}
+
+func baseRules(r Rules) Rules {
+	if r == nil {
+		return Standard{}
+	}
+	return r
+}
+
+func variantName(name string, base Rules) string {
+	if base == nil {
+		return name
+	}
+	if _, ok := base.(Standard); ok {
+		return name
+	}
+	return name + "+" + base.Name()
+}
+
+func copyLayout(layout [][]int) [][]int {
+	ret := make([][]int, len(layout))
+	for i, line := range layout {
+		ret[i] = append([]int(nil), line...)
+	}
+	return ret
+}
+
+func placeWall(layout [][]int, x, y int) error {
+	if y < 0 || y >= len(layout) || x < 0 || x >= len(layout[y]) {
+		return &LayoutError{Row: y, Col: x, Reason: "wall out of bounds"}
+	}
+	switch layout[y][x] {
+	case int(None), int(Wall):
+		layout[y][x] = int(Wall)
+		return nil
+	default:
+		return &LayoutError{Row: y, Col: x, Reason: fmt.Sprintf("wall on a %s disc", Color(layout[y][x]))}
+	}
+}
@@ -0,0 +1,294 @@
+package reversi
+
+import (
+	"errors"
+	"testing"
+)
+
+var errForbidden = errors.New("forbidden")
+
+// noCorners forbids playing on the corners of the board.
+type noCorners struct {
+	Standard
+}
+
+func (noCorners) Legal(b *Board, color Color, pos *Position) error {
+	if (pos.X == 0 || pos.X == b.Width-1) && (pos.Y == 0 || pos.Y == b.Height-1) {
+		return errForbidden
+	}
+	return nil
+}
+
+func TestBoard_wall(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		board    [][]int
+		pos      *Position
+		expected bool
+	}{
+		{
+			desc: "when wall ends the line",
+			board: [][]int{
+				{0, 2, 3, 1},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+			pos:      &Position{X: 0, Y: 0},
+			expected: false,
+		},
+		{
+			desc: "when wall is next to the cell",
+			board: [][]int{
+				{0, 3, 2, 1},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+			pos:      &Position{X: 0, Y: 0},
+			expected: false,
+		},
+		{
+			desc: "when cell is a wall",
+			board: [][]int{
+				{3, 2, 1, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+			pos:      &Position{X: 0, Y: 0},
+			expected: false,
+		},
+		{
+			desc: "when wall is elsewhere",
+			board: [][]int{
+				{0, 2, 1, 0},
+				{3, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+			pos:      &Position{X: 0, Y: 0},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		// Grow the 4x4 layout to 8x8 so that both engines are exercised.
+		large := DefaultLayout(8)
+		for i := range large {
+			for j := range large[i] {
+				large[i][j] = 0
+			}
+		}
+		for i, line := range tc.board {
+			copy(large[i], line)
+		}
+		for _, b := range []*Board{NewBoard(large), newGridBoard(large)} {
+			cell := b.Cell(tc.pos.X, tc.pos.Y)
+			if actual := b.canAllocate(Black, cell); actual != tc.expected {
+				t.Errorf("%s, got: %v, expected: %v", tc.desc, actual, tc.expected)
+			}
+			if b.Count(Wall) != 1 {
+				t.Errorf("%s, wall count got: %d", tc.desc, b.Count(Wall))
+			}
+			if b.Count(None) != 64-3 {
+				t.Errorf("%s, empty count got: %d", tc.desc, b.Count(None))
+			}
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	full := [][]int{
+		{1, 1, 1, 1},
+		{1, 2, 2, 2},
+		{2, 2, 2, 2},
+		{1, 1, 1, 0},
+	}
+	// The corners are left empty for the octagon to wall off. Black filling
+	// the last cell ends the game 14 to 10.
+	octagon := [][]int{
+		{0, 0, 1, 1, 0, 0},
+		{0, 1, 1, 1, 1, 0},
+		{1, 2, 2, 2, 2, 2},
+		{2, 2, 2, 2, 2, 2},
+		{0, 2, 2, 2, 2, 0},
+		{0, 0, 1, 0, 0, 0},
+	}
+	testcases := []struct {
+		desc   string
+		layout [][]int
+		rules  Rules
+		name   string
+		move   *Position
+		winner Color
+		err    error
+	}{
+		{
+			desc:   "when standard",
+			layout: full,
+			rules:  Standard{},
+			name:   "standard",
+			move:   &Position{X: 3, Y: 3},
+			winner: Black,
+		},
+		{
+			desc:   "when anti-reversi",
+			layout: full,
+			rules:  AntiReversi{},
+			name:   "anti",
+			move:   &Position{X: 3, Y: 3},
+			winner: White,
+		},
+		{
+			desc:   "when anti-reversi on an octagon",
+			layout: octagon,
+			rules:  AntiReversi{Base: Octagon{}},
+			name:   "anti+octagon",
+			move:   &Position{X: 3, Y: 5},
+			winner: White,
+		},
+		{
+			desc:   "when standard on an octagon",
+			layout: octagon,
+			rules:  Octagon{},
+			name:   "octagon",
+			move:   &Position{X: 3, Y: 5},
+			winner: Black,
+		},
+		{
+			desc:   "when an octagon would wall off discs",
+			layout: full,
+			rules:  Octagon{},
+			name:   "octagon",
+			err:    ErrLayout,
+		},
+	}
+
+	for _, tc := range testcases {
+		if tc.rules.Name() != tc.name {
+			t.Errorf("%s, name got: %s, expected: %s", tc.desc, tc.rules.Name(), tc.name)
+		}
+		game, err := NewGame(WithLayout(tc.layout), WithRules(tc.rules))
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%s, got: %v, expected: %v", tc.desc, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tc.desc, err)
+		}
+		game.Start()
+		if err := game.SetStone(Black, tc.move); err != nil {
+			t.Fatalf("%s: %v", tc.desc, err)
+		}
+		if game.GameState != Finish || game.EndReason() != BoardFull {
+			t.Errorf("%s, state: %s, reason: %s", tc.desc, game.GameState, game.EndReason())
+		}
+		if actual := game.Winner(); actual != tc.winner {
+			t.Errorf("%s, winner got: %s, expected: %s", tc.desc, actual, tc.winner)
+		}
+	}
+}
+
+func TestRules_Setup(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		rules    Rules
+		layout   [][]int
+		expected [][]int
+		err      error
+	}{
+		{
+			desc:   "when octagon",
+			rules:  Octagon{},
+			layout: DefaultLayout(6),
+			expected: [][]int{
+				{3, 3, 0, 0, 3, 3},
+				{3, 0, 0, 0, 0, 3},
+				{0, 0, 2, 1, 0, 0},
+				{0, 0, 1, 2, 0, 0},
+				{3, 0, 0, 0, 0, 3},
+				{3, 3, 0, 0, 3, 3},
+			},
+		},
+		{
+			desc:   "when octagon with cut 1",
+			rules:  Octagon{Cut: 1},
+			layout: DefaultLayout(4),
+			expected: [][]int{
+				{3, 0, 0, 3},
+				{0, 2, 1, 0},
+				{0, 1, 2, 0},
+				{3, 0, 0, 3},
+			},
+		},
+		{
+			desc:   "when blocked squares",
+			rules:  Blocked{Squares: []Position{{X: 0, Y: 1}, {X: 3, Y: 2}}},
+			layout: DefaultLayout(4),
+			expected: [][]int{
+				{0, 0, 0, 0},
+				{3, 2, 1, 0},
+				{0, 1, 2, 3},
+				{0, 0, 0, 0},
+			},
+		},
+		{
+			desc:   "when blocked square holds a disc",
+			rules:  Blocked{Squares: []Position{{X: 1, Y: 1}}},
+			layout: DefaultLayout(4),
+			err:    ErrLayout,
+		},
+		{
+			desc:   "when blocked square is out of bounds",
+			rules:  Blocked{Squares: []Position{{X: 4, Y: 1}}},
+			layout: DefaultLayout(4),
+			err:    ErrLayout,
+		},
+	}
+
+	for _, tc := range testcases {
+		original := copyLayout(tc.layout)
+		actual, err := tc.rules.Setup(tc.layout)
+		if !matchArray(tc.layout, original) {
+			t.Errorf("%s, Setup changed the layout", tc.desc)
+		}
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%s, got: %v, expected: %v", tc.desc, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !matchArray(actual, tc.expected) {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, actual, tc.expected)
+		}
+	}
+}
+
+func TestRules_Legal(t *testing.T) {
+	game, err := NewGame(WithLayout([][]int{
+		{0, 2, 1, 0},
+		{2, 0, 0, 0},
+		{1, 0, 0, 0},
+		{0, 0, 0, 0},
+	}), WithRules(noCorners{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moves := game.ListAllocatablePositions(Black); len(moves) != 0 {
+		t.Errorf("got %d moves, expected none", len(moves))
+	}
+	game.Start()
+	if game.GameState != Finish || game.EndReason() != BothBlocked {
+		t.Errorf("state: %s, reason: %s", game.GameState, game.EndReason())
+	}
+	game.GameState = BlackTurn
+	if err := game.SetStone(Black, &Position{X: 0, Y: 0}); !errors.Is(err, errForbidden) {
+		t.Errorf("got: %v, expected: %v", err, errForbidden)
+	}
+}
@@ -0,0 +1,244 @@
+package reversi
+
+import (
+	"math/bits"
+
+	bits64 "github.com/myoan/go-reversi/internal/bitboard"
+)
+
+// Transform is one of the eight symmetries of a square board. The quarter
+// turns and the diagonal flips swap the width and height of a board, so
+// non-square boards only have Identity, Rotate180, FlipHorizontal and
+// FlipVertical as symmetries.
+type Transform int
+
+const (
+	Identity         Transform = iota
+	Rotate90                   // quarter turn clockwise
+	Rotate180                  // half turn
+	Rotate270                  // quarter turn counterclockwise
+	FlipHorizontal             // mirror left to right
+	FlipVertical               // mirror top to bottom
+	FlipDiagonal               // mirror along the a1-h8 diagonal
+	FlipAntiDiagonal           // mirror along the h1-a8 diagonal
+)
+
+// Transforms lists every Transform, Identity first.
+var Transforms = []Transform{
+	Identity, Rotate90, Rotate180, Rotate270,
+	FlipHorizontal, FlipVertical, FlipDiagonal, FlipAntiDiagonal,
+}
+
+func (t Transform) String() string {
+	switch t {
+	case Identity:
+		return "identity"
+	case Rotate90:
+		return "rotate90"
+	case Rotate180:
+		return "rotate180"
+	case Rotate270:
+		return "rotate270"
+	case FlipHorizontal:
+		return "flip-horizontal"
+	case FlipVertical:
+		return "flip-vertical"
+	case FlipDiagonal:
+		return "flip-diagonal"
+	case FlipAntiDiagonal:
+		return "flip-antidiagonal"
+	default:
+		return "unknown"
+	}
+}
+
+// Inverse returns the transform undoing t.
+func (t Transform) Inverse() Transform {
+	switch t {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	default:
+		return t
+	}
+}
+
+// Then returns the transform applying t, then next.
+func (t Transform) Then(next Transform) Transform {
+	// The result of a composition is found by where it sends two cells of a
+	// 3x3 board, which no two transforms agree on.
+	a := next.Position(t.Position(&Position{X: 0, Y: 0}, 3, 3), 3, 3)
+	b := next.Position(t.Position(&Position{X: 1, Y: 0}, 3, 3), 3, 3)
+	for _, c := range Transforms {
+		if *c.Position(&Position{X: 0, Y: 0}, 3, 3) == *a && *c.Position(&Position{X: 1, Y: 0}, 3, 3) == *b {
+			return c
+		}
+	}
+	return Identity
+}
+
+// Swaps reports whether t swaps the width and height of a board.
+func (t Transform) Swaps() bool {
+	switch t {
+	case Rotate90, Rotate270, FlipDiagonal, FlipAntiDiagonal:
+		return true
+	default:
+		return false
+	}
+}
+
+// Position maps pos on a board of width by height to the cell it moves to
+// under t. A nil pos, standing for a pass, maps to nil.
+func (t Transform) Position(pos *Position, width, height int) *Position {
+	if pos == nil {
+		return nil
+	}
+	x, y := pos.X, pos.Y
+	switch t {
+	case Rotate90:
+		x, y = height-1-y, x
+	case Rotate180:
+		x, y = width-1-x, height-1-y
+	case Rotate270:
+		x, y = y, width-1-x
+	case FlipHorizontal:
+		x = width - 1 - x
+	case FlipVertical:
+		y = height - 1 - y
+	case FlipDiagonal:
+		x, y = y, x
+	case FlipAntiDiagonal:
+		x, y = height-1-y, width-1-x
+	}
+	return &Position{X: x, Y: y}
+}
+
+// Transform returns a copy of b transformed by t.
+func (b *Board) Transform(t Transform) *Board {
+	if b.bits != nil {
+		bb := bitboard{
+			black: transformBits(t, b.bits.black),
+			white: transformBits(t, b.bits.white),
+			walls: transformBits(t, b.bits.walls),
+		}
+		ret := &Board{bits: &bb, Width: b.Width, Height: b.Height, logger: b.logger}
+		ret.hash = ret.computeHash()
+		return ret
+	}
+
+	width, height := b.Width, b.Height
+	if t.Swaps() {
+		width, height = height, width
+	}
+	init := make([][]int, height)
+	for i := range init {
+		init[i] = make([]int, width)
+	}
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			p := t.Position(&Position{X: j, Y: i}, b.Width, b.Height)
+			init[p.Y][p.X] = int(b.Cell(j, i).State)
+		}
+	}
+	ret := newGridBoard(init)
+	ret.logger = b.logger
+	return ret
+}
+
+// Symmetries returns the transforms mapping b to a board of the same size:
+// all eight on square boards, and the four keeping the width and height
+// otherwise.
+func (b *Board) Symmetries() []Transform {
+	if b.Width == b.Height {
+		return Transforms
+	}
+	ret := make([]Transform, 0, 4)
+	for _, t := range Transforms {
+		if !t.Swaps() {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+// Canonical returns the smallest of the boards b transforms to under its
+// Symmetries, and the transform taking b there. Boards are ordered by their
+// cell states, row by row from a1. Positions equal up to symmetry have the
+// same canonical board, and so the same Hash. A move on b maps to the
+// canonical board with t.Position, and back with t.Inverse().Position.
+func (b *Board) Canonical() (*Board, Transform) {
+	if b.bits != nil {
+		best, bestT := *b.bits, Identity
+		for _, t := range Transforms[1:] {
+			bb := bitboard{
+				black: transformBits(t, b.bits.black),
+				white: transformBits(t, b.bits.white),
+				walls: transformBits(t, b.bits.walls),
+			}
+			if bb.less(&best) {
+				best, bestT = bb, t
+			}
+		}
+		if bestT == Identity {
+			return b.Clone(), Identity
+		}
+		ret := &Board{bits: &best, Width: b.Width, Height: b.Height, logger: b.logger}
+		ret.hash = ret.computeHash()
+		return ret, bestT
+	}
+
+	best, bestT := b.Clone(), Identity
+	for _, t := range b.Symmetries()[1:] {
+		if c := b.Transform(t); c.less(best) {
+			best, bestT = c, t
+		}
+	}
+	return best, bestT
+}
+
+// less reports whether b comes before other in the order of Canonical. Both
+// boards have the same size.
+func (b *Board) less(other *Board) bool {
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			s, o := b.Cell(j, i).State, other.Cell(j, i).State
+			if s != o {
+				return s < o
+			}
+		}
+	}
+	return false
+}
+
+// less compares bitboards in the order of Board.less: the first differing
+// cell decides, with None < Black < White < Wall.
+func (bb *bitboard) less(other *bitboard) bool {
+	diff := (bb.black ^ other.black) | (bb.white ^ other.white) | (bb.walls ^ other.walls)
+	if diff == 0 {
+		return false
+	}
+	i := bits.TrailingZeros64(diff)
+	return bb.state(i%bitboardSize, i/bitboardSize) < other.state(i%bitboardSize, i/bitboardSize)
+}
+
+func transformBits(t Transform, m uint64) uint64 {
+	switch t {
+	case Rotate90:
+		return bits64.MirrorHorizontal(bits64.Transpose(m))
+	case Rotate180:
+		return bits64.FlipVertical(bits64.MirrorHorizontal(m))
+	case Rotate270:
+		return bits64.FlipVertical(bits64.Transpose(m))
+	case FlipHorizontal:
+		return bits64.MirrorHorizontal(m)
+	case FlipVertical:
+		return bits64.FlipVertical(m)
+	case FlipDiagonal:
+		return bits64.Transpose(m)
+	case FlipAntiDiagonal:
+		return bits64.FlipVertical(bits64.MirrorHorizontal(bits64.Transpose(m)))
+	default:
+		return m
+	}
+}
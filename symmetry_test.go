@@ -0,0 +1,145 @@
+package reversi
+
+import (
+	"testing"
+)
+
+func TestBoard_Transform(t *testing.T) {
+	game, err := ParseTranscript("f5d6c3d3c4f4c5b3c2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := game.board.Clone()
+	board.set(0, 7, Wall)
+	grid := newGridBoard(board.toArray())
+
+	for _, tr := range Transforms {
+		got := board.Transform(tr)
+		expected := grid.Transform(tr)
+		if !got.Equal(expected) {
+			t.Errorf("%s, got: %v, expected: %v", tr, got.toArray(), expected.toArray())
+		}
+		if got.Hash() != got.computeHash() {
+			t.Errorf("%s, got hash: %x, expected: %x", tr, got.Hash(), got.computeHash())
+		}
+		for i := 0; i < board.Height; i++ {
+			for j := 0; j < board.Width; j++ {
+				p := tr.Position(&Position{X: j, Y: i}, board.Width, board.Height)
+				if got.Cell(p.X, p.Y).State != board.Cell(j, i).State {
+					t.Errorf("%s, cell (%d, %d) maps to (%d, %d) with a different state", tr, j, i, p.X, p.Y)
+				}
+			}
+		}
+		if back := got.Transform(tr.Inverse()); !back.Equal(board) {
+			t.Errorf("%s, got: %v, expected: %v", tr, back.toArray(), board.toArray())
+		}
+	}
+}
+
+func TestBoard_Transform_rectangle(t *testing.T) {
+	board := NewBoard([][]int{
+		{1, 0, 0},
+		{0, 2, 0},
+	})
+	testcases := []struct {
+		desc     string
+		t        Transform
+		expected [][]int
+	}{
+		{desc: "when rotating clockwise", t: Rotate90, expected: [][]int{{0, 1}, {2, 0}, {0, 0}}},
+		{desc: "when rotating half a turn", t: Rotate180, expected: [][]int{{0, 2, 0}, {0, 0, 1}}},
+		{desc: "when rotating counterclockwise", t: Rotate270, expected: [][]int{{0, 0}, {0, 2}, {1, 0}}},
+		{desc: "when flipping horizontally", t: FlipHorizontal, expected: [][]int{{0, 0, 1}, {0, 2, 0}}},
+		{desc: "when flipping diagonally", t: FlipDiagonal, expected: [][]int{{1, 0}, {0, 2}, {0, 0}}},
+		{desc: "when flipping antidiagonally", t: FlipAntiDiagonal, expected: [][]int{{0, 0}, {2, 0}, {0, 1}}},
+	}
+	for _, tc := range testcases {
+		got := board.Transform(tc.t)
+		if !got.Equal(NewBoard(tc.expected)) {
+			t.Errorf("%s, got: %v, expected: %v", tc.desc, got.toArray(), tc.expected)
+		}
+	}
+	if got := len(board.Symmetries()); got != 4 {
+		t.Errorf("got: %d symmetries, expected: 4", got)
+	}
+}
+
+func TestTransform_Position(t *testing.T) {
+	for _, tr := range Transforms {
+		for _, size := range [][2]int{{8, 8}, {6, 4}} {
+			w, h := size[0], size[1]
+			if tr.Swaps() {
+				w, h = h, w
+			}
+			for y := 0; y < size[1]; y++ {
+				for x := 0; x < size[0]; x++ {
+					pos := &Position{X: x, Y: y}
+					p := tr.Position(pos, size[0], size[1])
+					if p.X < 0 || p.X >= w || p.Y < 0 || p.Y >= h {
+						t.Fatalf("%s, got: %v, expected within %dx%d", tr, p, w, h)
+					}
+					if back := tr.Inverse().Position(p, w, h); *back != *pos {
+						t.Errorf("%s, got: %v, expected: %v", tr, back, pos)
+					}
+				}
+			}
+		}
+		if tr.Position(nil, 8, 8) != nil {
+			t.Errorf("%s, got: a position, expected: nil for a pass", tr)
+		}
+		if got := tr.Then(tr.Inverse()); got != Identity {
+			t.Errorf("%s, got: %s, expected: %s", tr, got, Identity)
+		}
+	}
+	if got := Rotate90.Then(Rotate90); got != Rotate180 {
+		t.Errorf("got: %s, expected: %s", got, Rotate180)
+	}
+	if got := FlipHorizontal.Then(FlipVertical); got != Rotate180 {
+		t.Errorf("got: %s, expected: %s", got, Rotate180)
+	}
+}
+
+func TestBoard_Canonical(t *testing.T) {
+	testcases := []struct {
+		desc string
+		init [][]int
+	}{
+		{desc: "when 8x8", init: InitBoard},
+		{desc: "when 6x6", init: [][]int{
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 2, 1, 0, 0},
+			{0, 0, 1, 2, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+		}},
+	}
+	for _, tc := range testcases {
+		board := NewBoard(tc.init)
+		var canonical *Board
+		for _, pos := range board.ListAllocatablePositions(Black) {
+			next, err := board.Apply(Black, pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, tr := next.Canonical()
+			if !next.Transform(tr).Equal(c) {
+				t.Errorf("%s, got: %v, expected the board transformed by %s", tc.desc, c.toArray(), tr)
+			}
+			if canonical == nil {
+				canonical = c
+			} else if !c.Equal(canonical) || c.Hash() != canonical.Hash() {
+				t.Errorf("%s, got: %v after %v, expected: %v", tc.desc, c.toArray(), pos, canonical.toArray())
+			}
+			for _, other := range next.Symmetries() {
+				if next.Transform(other).less(c) {
+					t.Errorf("%s, got: %v, expected smaller %v", tc.desc, c.toArray(), next.Transform(other).toArray())
+				}
+			}
+			move := tr.Position(pos, board.Width, board.Height)
+			if c.Cell(move.X, move.Y).State != Black {
+				t.Errorf("%s, got: %v, expected a black disc at %v", tc.desc, c.Cell(move.X, move.Y).State, move)
+			}
+		}
+	}
+}
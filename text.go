@@ -0,0 +1,147 @@
+package reversi
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MarshalText encodes the board on one line, row by row from a1: "X" for
+// black, "O" for white, "-" for an empty cell and "#" for a wall. This is the
+// board part of the OBF format used by endgame test suites.
+func (b *Board) MarshalText() ([]byte, error) {
+	ret := make([]byte, 0, b.Width*b.Height)
+	for i := 0; i < b.Height; i++ {
+		for j := 0; j < b.Width; j++ {
+			c, ok := textCells[b.Cell(j, i).State]
+			if !ok {
+				return nil, fmt.Errorf("%w: cell (%d, %d) has state %d", ErrNotation, j, i, int(b.Cell(j, i).State))
+			}
+			ret = append(ret, c)
+		}
+	}
+	return ret, nil
+}
+
+// UnmarshalText decodes a square board written by MarshalText. "*" is also
+// accepted for black, letters may be lower case, and anything after the
+// cells, such as the side to move of an OBF line, is ignored.
+func (b *Board) UnmarshalText(text []byte) error {
+	cells, _, err := splitOBF(string(text))
+	if err != nil {
+		return err
+	}
+	layout, err := parseTextBoard(cells)
+	if err != nil {
+		return err
+	}
+	logger := b.logger
+	*b = *NewBoard(layout)
+	b.logger = logger
+	return nil
+}
+
+// MarshalText encodes the game position as an OBF line: the board followed
+// by the side to move, "X" or "O", or "-" once the game is finished.
+func (game *Game) MarshalText() ([]byte, error) {
+	board, err := game.board.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	turn := game.GameState.Turn()
+	if game.GameState == Prepare {
+		turn = baseColor(game.first)
+	}
+	side := byte('-')
+	if turn.Valid() {
+		side = textCells[turn]
+	}
+	return append(append(board, ' '), side), nil
+}
+
+// UnmarshalText sets up the game from an OBF line such as the starting
+// position
+//
+//	---------------------------OX------XO--------------------------- X;
+//
+// The game starts from that position with no history, under its current
+// rules. If the side to move has no legal move a pass is recorded. A side to
+// move of "-" restores a finished game, and is rejected when either side can
+// still move.
+func (game *Game) UnmarshalText(text []byte) error {
+	cells, side, err := splitOBF(string(text))
+	if err != nil {
+		return err
+	}
+	layout, err := parseTextBoard(cells)
+	if err != nil {
+		return err
+	}
+	first := Black
+	switch side {
+	case "X", "x", "*", "-":
+	case "O", "o":
+		first = White
+	default:
+		return fmt.Errorf("%w: unknown side to move %q", ErrNotation, side)
+	}
+	board := NewBoard(layout)
+	board.SetLogger(game.logger)
+	next := Game{board: board, first: first, rules: game.rules, logger: game.logger}
+	next.Start()
+	if side == "-" && next.GameState != Finish {
+		return fmt.Errorf("%w: finished game with moves left", ErrNotation)
+	}
+	*game = next
+	return nil
+}
+
+var textCells = map[Color]byte{
+	None:  '-',
+	Black: 'X',
+	White: 'O',
+	Wall:  '#',
+}
+
+// splitOBF splits an OBF line into its board and side to move, dropping
+// anything after the first semicolon.
+func splitOBF(s string) (string, string, error) {
+	if i := strings.IndexByte(s, ';'); i >= 0 {
+		s = s[:i]
+	}
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 0:
+		return "", "", fmt.Errorf("%w: empty position", ErrNotation)
+	case 1:
+		return fields[0], "", nil
+	default:
+		return fields[0], fields[1], nil
+	}
+}
+
+func parseTextBoard(cells string) ([][]int, error) {
+	size := int(math.Sqrt(float64(len(cells))))
+	if size == 0 || size*size != len(cells) {
+		return nil, fmt.Errorf("%w: %d cells do not make a square board", ErrNotation, len(cells))
+	}
+	layout := make([][]int, size)
+	for i := range layout {
+		layout[i] = make([]int, size)
+		for j := range layout[i] {
+			switch c := cells[i*size+j]; c {
+			case '-', '.':
+				layout[i][j] = int(None)
+			case 'X', 'x', '*':
+				layout[i][j] = int(Black)
+			case 'O', 'o':
+				layout[i][j] = int(White)
+			case '#':
+				layout[i][j] = int(Wall)
+			default:
+				return nil, fmt.Errorf("%w: unknown cell %q", ErrNotation, c)
+			}
+		}
+	}
+	return layout, nil
+}
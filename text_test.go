@@ -0,0 +1,165 @@
+package reversi
+
+import (
+	"errors"
+	"testing"
+)
+
+const initText = "---------------------------OX------XO---------------------------"
+
+func TestBoard_MarshalText(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		board    [][]int
+		expected string
+	}{
+		{
+			desc:     "when initial board",
+			board:    InitBoard,
+			expected: initText,
+		},
+		{
+			desc: "when 4x4 with a wall",
+			board: [][]int{
+				{3, 0, 0, 0},
+				{0, 2, 1, 0},
+				{0, 1, 2, 0},
+				{0, 0, 0, 0},
+			},
+			expected: "#----OX--XO-----",
+		},
+	}
+	for _, tc := range testcases {
+		b := NewBoard(tc.board)
+		actual, err := b.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(actual) != tc.expected {
+			t.Errorf("%s, got: %s, expected: %s", tc.desc, actual, tc.expected)
+		}
+		var parsed Board
+		if err := parsed.UnmarshalText(actual); err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equal(b) {
+			t.Errorf("%s, round trip differs", tc.desc)
+		}
+	}
+}
+
+func TestBoard_UnmarshalText(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		input    string
+		expected [][]int
+	}{
+		{
+			desc:     "when OBF line",
+			input:    initText + " X; % comment",
+			expected: InitBoard,
+		},
+		{
+			desc:  "when lower case and stars",
+			input: "....-ox--*o-----",
+			expected: [][]int{
+				{0, 0, 0, 0},
+				{0, 2, 1, 0},
+				{0, 1, 2, 0},
+				{0, 0, 0, 0},
+			},
+		},
+		{desc: "when not square", input: "---"},
+		{desc: "when unknown cell", input: "---?"},
+		{desc: "when empty", input: "  "},
+	}
+	for _, tc := range testcases {
+		var b Board
+		err := b.UnmarshalText([]byte(tc.input))
+		if tc.expected == nil {
+			if !errors.Is(err, ErrNotation) {
+				t.Errorf("%s, got: %v, expected: ErrNotation", tc.desc, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !matchArray(b.toArray(), tc.expected) {
+			t.Errorf("%s, unexpected board", tc.desc)
+			b.Show()
+		}
+	}
+}
+
+func TestGame_MarshalText(t *testing.T) {
+	game, err := ParseTranscript("f5d6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := game.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "---------------------------OX------OXX-----O-------------------- X"
+	if string(text) != expected {
+		t.Errorf("got: %s, expected: %s", text, expected)
+	}
+
+	var parsed Game
+	if err := parsed.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.board.Equal(game.board) || parsed.GameState != BlackTurn || parsed.Ply() != 0 {
+		t.Errorf("round trip differs, state: %s", parsed.GameState)
+	}
+
+	if err := parsed.UnmarshalText([]byte(initText + " O")); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.GameState != WhiteTurn {
+		t.Errorf("state got: %s, expected: %s", parsed.GameState, WhiteTurn)
+	}
+	if err := parsed.UnmarshalText([]byte(initText + " ?")); !errors.Is(err, ErrNotation) {
+		t.Errorf("got: %v, expected: ErrNotation", err)
+	}
+	if err := parsed.UnmarshalText([]byte(initText + " -")); !errors.Is(err, ErrNotation) {
+		t.Errorf("got: %v, expected: ErrNotation for a running game marked finished", err)
+	}
+}
+
+func TestGame_MarshalText_finished(t *testing.T) {
+	game, err := NewGame(WithSize(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.Start()
+	for game.GameState != Finish {
+		color := game.GameState.Turn()
+		if err := game.SetStone(color, game.ListAllocatablePositions(color)[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	text, err := game.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text[len(text)-1] != '-' {
+		t.Fatalf("got: %s, expected a finished side to move", text)
+	}
+
+	var parsed Game
+	if err := parsed.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.board.Equal(game.board) || parsed.GameState != Finish || parsed.EndReason() != game.EndReason() {
+		t.Errorf("got: %s (%s), expected: %s (%s)", parsed.GameState, parsed.EndReason(), Finish, game.EndReason())
+	}
+	again, err := parsed.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(text) {
+		t.Errorf("got: %s, expected: %s", again, text)
+	}
+}
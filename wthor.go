@@ -0,0 +1,173 @@
+package reversi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	wthorHeaderSize     = 16
+	wthorGameSize       = 68
+	wthorPlayerSize     = 20
+	wthorTournamentSize = 26
+)
+
+// WthorHeader is the 16-byte header shared by WTHOR game (.wtb), player
+// (.jou) and tournament (.trn) files.
+type WthorHeader struct {
+	Created   time.Time
+	Games     int // number of game records in a .wtb file
+	Records   int // number of names in a .jou or .trn file
+	Year      int // year the games were played
+	BoardSize int
+	Solitaire bool
+	Depth     int // depth from which theoretical scores were computed
+}
+
+// WthorGame is a game record of a .wtb file. Players and tournaments are
+// indices into the names read from the .jou and .trn files.
+type WthorGame struct {
+	Tournament       int
+	Black            int
+	White            int
+	Score            int // black discs at the end of the game
+	TheoreticalScore int // black discs with perfect play from Depth empties
+	Moves            []Position
+}
+
+// Game replays the record from the standard starting position, inserting
+// passes where needed.
+func (g *WthorGame) Game() (*Game, error) {
+	game, err := NewGame()
+	if err != nil {
+		return nil, err
+	}
+	game.Start()
+	for i := range g.Moves {
+		pos := &g.Moves[i]
+		if err := game.SetStone(game.GameState.Turn(), pos); err != nil {
+			return nil, fmt.Errorf("WTHOR move %d (%s): %w", i+1, pos, err)
+		}
+	}
+	return game, nil
+}
+
+// WthorReader streams the games of a .wtb file.
+type WthorReader struct {
+	Header WthorHeader
+	r      io.Reader
+	read   int
+	buf    [wthorGameSize]byte
+}
+
+// NewWthorReader reads the header of a .wtb file. Only 8x8 games are
+// supported.
+func NewWthorReader(r io.Reader) (*WthorReader, error) {
+	h, err := readWthorHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	if h.BoardSize != 8 {
+		return nil, fmt.Errorf("%w: unsupported board size %d", ErrWthor, h.BoardSize)
+	}
+	return &WthorReader{Header: *h, r: r}, nil
+}
+
+// Next returns the next game, or io.EOF after the last one.
+func (r *WthorReader) Next() (*WthorGame, error) {
+	if r.Header.Games > 0 && r.read >= r.Header.Games {
+		return nil, io.EOF
+	}
+	if _, err := io.ReadFull(r.r, r.buf[:]); err != nil {
+		if err == io.EOF && r.Header.Games == 0 {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%w: game %d: %v", ErrWthor, r.read+1, err)
+	}
+	r.read++
+
+	b := r.buf[:]
+	g := &WthorGame{
+		Tournament:       int(binary.LittleEndian.Uint16(b[0:2])),
+		Black:            int(binary.LittleEndian.Uint16(b[2:4])),
+		White:            int(binary.LittleEndian.Uint16(b[4:6])),
+		Score:            int(b[6]),
+		TheoreticalScore: int(b[7]),
+	}
+	for _, m := range b[8:] {
+		if m == 0 {
+			break
+		}
+		row, col := int(m/10), int(m%10)
+		if row < 1 || row > 8 || col < 1 || col > 8 {
+			return nil, fmt.Errorf("%w: game %d: bad move %d", ErrWthor, r.read, m)
+		}
+		g.Moves = append(g.Moves, Position{X: col - 1, Y: row - 1})
+	}
+	return g, nil
+}
+
+// ReadWthorPlayers reads the player names of a .jou file.
+func ReadWthorPlayers(r io.Reader) ([]string, error) {
+	return readWthorNames(r, wthorPlayerSize)
+}
+
+// ReadWthorTournaments reads the tournament names of a .trn file.
+func ReadWthorTournaments(r io.Reader) ([]string, error) {
+	return readWthorNames(r, wthorTournamentSize)
+}
+
+func readWthorNames(r io.Reader, size int) ([]string, error) {
+	h, err := readWthorHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]string, 0, h.Records)
+	buf := make([]byte, size)
+	for i := 0; i < h.Records; i++ {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, fmt.Errorf("%w: name %d: %v", ErrWthor, i+1, err)
+		}
+		ret = append(ret, latin1(buf))
+	}
+	return ret, nil
+}
+
+func readWthorHeader(r io.Reader) (*WthorHeader, error) {
+	var b [wthorHeaderSize]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: short header", ErrWthor)
+		}
+		return nil, err
+	}
+	h := &WthorHeader{
+		Created:   time.Date(int(b[0])*100+int(b[1]), time.Month(b[2]), int(b[3]), 0, 0, 0, 0, time.UTC),
+		Games:     int(binary.LittleEndian.Uint32(b[4:8])),
+		Records:   int(binary.LittleEndian.Uint16(b[8:10])),
+		Year:      int(binary.LittleEndian.Uint16(b[10:12])),
+		BoardSize: int(b[12]),
+		Solitaire: b[13] == 1,
+		Depth:     int(b[14]),
+	}
+	if h.BoardSize == 0 {
+		h.BoardSize = 8
+	}
+	return h, nil
+}
+
+// latin1 decodes a NUL-padded ISO-8859-1 name.
+func latin1(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		if c == 0 {
+			break
+		}
+		sb.WriteRune(rune(c))
+	}
+	return strings.TrimSpace(sb.String())
+}
@@ -0,0 +1,123 @@
+package reversi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func wthorHeader(games, records int) []byte {
+	b := make([]byte, wthorHeaderSize)
+	b[0], b[1], b[2], b[3] = 20, 24, 3, 9
+	binary.LittleEndian.PutUint32(b[4:8], uint32(games))
+	binary.LittleEndian.PutUint16(b[8:10], uint16(records))
+	binary.LittleEndian.PutUint16(b[10:12], 2023)
+	b[12], b[13], b[14] = 8, 0, 22
+	return b
+}
+
+func wthorRecord(tournament, black, white, score, theoretical int, moves []byte) []byte {
+	b := make([]byte, wthorGameSize)
+	binary.LittleEndian.PutUint16(b[0:2], uint16(tournament))
+	binary.LittleEndian.PutUint16(b[2:4], uint16(black))
+	binary.LittleEndian.PutUint16(b[4:6], uint16(white))
+	b[6], b[7] = byte(score), byte(theoretical)
+	copy(b[8:], moves)
+	return b
+}
+
+func TestWthorReader(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(wthorHeader(2, 0))
+	buf.Write(wthorRecord(3, 10, 11, 40, 38, []byte{56, 64, 33, 34, 43}))
+	buf.Write(wthorRecord(4, 12, 13, 0, 0, []byte{56, 11}))
+
+	r, err := NewWthorReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := r.Header
+	if h.Games != 2 || h.Year != 2023 || h.BoardSize != 8 || h.Depth != 22 || !h.Created.Equal(time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected header: %+v", h)
+	}
+
+	g, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Tournament != 3 || g.Black != 10 || g.White != 11 || g.Score != 40 || g.TheoreticalScore != 38 {
+		t.Errorf("unexpected game: %+v", g)
+	}
+	game, err := g.Game()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Transcript() != "f5d6c3d3c4" {
+		t.Errorf("got: %s, expected: f5d6c3d3c4", game.Transcript())
+	}
+
+	g, err = r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Game(); !errors.Is(err, ErrNoFlips) {
+		t.Errorf("got: %v, expected: ErrNoFlips", err)
+	}
+
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("got: %v, expected: io.EOF", err)
+	}
+}
+
+func TestWthorReader_errors(t *testing.T) {
+	testcases := []struct {
+		desc  string
+		input []byte
+	}{
+		{desc: "when header is short", input: wthorHeader(1, 0)[:10]},
+		{desc: "when record is truncated", input: append(wthorHeader(1, 0), wthorRecord(0, 0, 0, 0, 0, []byte{56})[:30]...)},
+		{desc: "when move is out of range", input: append(wthorHeader(1, 0), wthorRecord(0, 0, 0, 0, 0, []byte{56, 19})...)},
+	}
+	for _, tc := range testcases {
+		r, err := NewWthorReader(bytes.NewReader(tc.input))
+		if err == nil {
+			_, err = r.Next()
+		}
+		if !errors.Is(err, ErrWthor) {
+			t.Errorf("%s, got: %v, expected: ErrWthor", tc.desc, err)
+		}
+	}
+
+	header := wthorHeader(1, 0)
+	header[12] = 10
+	if _, err := NewWthorReader(bytes.NewReader(header)); !errors.Is(err, ErrWthor) {
+		t.Errorf("10x10, got: %v, expected: ErrWthor", err)
+	}
+}
+
+func TestReadWthorPlayers(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(wthorHeader(0, 2))
+	for _, name := range [][]byte{[]byte("Tastet Marc"), {'L', 0xe9, 'v', 'y'}} {
+		record := make([]byte, wthorPlayerSize)
+		copy(record, name)
+		buf.Write(record)
+	}
+	names, err := ReadWthorPlayers(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "Tastet Marc" || names[1] != "Lévy" {
+		t.Errorf("got: %q", names)
+	}
+
+	buf.Reset()
+	buf.Write(wthorHeader(0, 1))
+	buf.Write(make([]byte, wthorTournamentSize-1))
+	if _, err := ReadWthorTournaments(&buf); !errors.Is(err, ErrWthor) {
+		t.Errorf("got: %v, expected: ErrWthor", err)
+	}
+}